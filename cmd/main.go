@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -8,7 +9,9 @@ import (
 
 	_ "github.com/Ntisrangga142/API_tickytiz/docs"
 	"github.com/Ntisrangga142/API_tickytiz/internals/configs"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
 	"github.com/Ntisrangga142/API_tickytiz/internals/routers"
+	"github.com/Ntisrangga142/API_tickytiz/internals/workers"
 	"github.com/joho/godotenv"
 )
 
@@ -37,6 +40,15 @@ func main() {
 
 	rdb := configs.InitRedis()
 
+	// Background Worker
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	seatRepo := repositories.NewSeatRepository(db, rdb)
+	waitlistWorker := workers.NewWaitlistWorker(seatRepo, rdb, configs.WaitlistInterval(), configs.WaitlistOfferDuration(), configs.AccessibleReleaseDuration())
+	go waitlistWorker.Run(ctx)
+	expiryWorker := workers.NewOrderExpiryWorker(repositories.NewOrderRepo(db), seatRepo, rdb, configs.OrderExpiryInterval(), waitlistWorker)
+	go expiryWorker.Run(ctx)
+
 	// Init Router
 	router := routers.InitRouter(db, rdb)
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,11 @@
+ALTER TABLE public.orderdetails DROP CONSTRAINT orderdetails_schedule_seat_uq;
+
+INSERT INTO public.orderdetails (id_order, id_seat, id_schedule)
+SELECT id_order, id_seat, id_schedule
+FROM public.orderdetails_conflict;
+
+DROP TABLE public.orderdetails_conflict;
+
+ALTER TABLE public.orderdetails
+  DROP CONSTRAINT fk_id_schedule_details,
+  DROP COLUMN id_schedule;
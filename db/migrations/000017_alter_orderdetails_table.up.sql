@@ -0,0 +1,41 @@
+ALTER TABLE public.orderdetails ADD COLUMN id_schedule INTEGER;
+
+UPDATE public.orderdetails od
+SET id_schedule = o.id_schedule
+FROM public.orders o
+WHERE o.id = od.id_order;
+
+-- sebelum constraint ini ada satu kursi bisa terjual ke beberapa order di schedule yang sama.
+-- Yang dipertahankan order yang sudah lunas lalu order paling awal, baris lainnya dipindah ke orderdetails_conflict
+-- supaya pemesanannya bisa ditindaklanjuti (refund / pindah kursi) dan unique constraint bisa dipasang.
+CREATE TABLE public.orderdetails_conflict (
+  id_order    INTEGER      NOT NULL,
+  id_seat     VARCHAR(255) NOT NULL,
+  id_schedule INTEGER      NOT NULL,
+  kept_order  INTEGER      NOT NULL,
+  flagged_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+  CONSTRAINT orderdetails_conflict_pk PRIMARY KEY (id_order, id_seat)
+);
+
+WITH ranked AS (
+  SELECT
+    od.id_order, od.id_seat, od.id_schedule,
+    FIRST_VALUE(od.id_order) OVER w AS kept_order,
+    ROW_NUMBER() OVER w AS rn
+  FROM public.orderdetails od
+  JOIN public.orders o ON o.id = od.id_order
+  WINDOW w AS (PARTITION BY od.id_schedule, od.id_seat ORDER BY COALESCE(o.ispaid, FALSE) DESC, od.id_order)
+)
+INSERT INTO public.orderdetails_conflict (id_order, id_seat, id_schedule, kept_order)
+SELECT id_order, id_seat, id_schedule, kept_order
+FROM ranked
+WHERE rn > 1;
+
+DELETE FROM public.orderdetails od
+USING public.orderdetails_conflict c
+WHERE c.id_order = od.id_order AND c.id_seat = od.id_seat;
+
+ALTER TABLE public.orderdetails
+  ALTER COLUMN id_schedule SET NOT NULL,
+  ADD CONSTRAINT fk_id_schedule_details FOREIGN KEY (id_schedule) REFERENCES public.schedule (id),
+  ADD CONSTRAINT orderdetails_schedule_seat_uq UNIQUE (id_schedule, id_seat);
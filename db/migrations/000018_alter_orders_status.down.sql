@@ -0,0 +1,16 @@
+DROP INDEX public.orders_status_expires_at_idx;
+
+ALTER TABLE public.orders ADD COLUMN ispaid BOOLEAN;
+
+UPDATE public.orders SET ispaid = (status = 'paid');
+
+ALTER TABLE public.orders
+  DROP CONSTRAINT orders_status_check,
+  DROP COLUMN status,
+  DROP COLUMN create_at,
+  DROP COLUMN expires_at,
+  DROP COLUMN awaiting_payment_at,
+  DROP COLUMN paid_at,
+  DROP COLUMN expired_at,
+  DROP COLUMN cancelled_at,
+  DROP COLUMN refunded_at;
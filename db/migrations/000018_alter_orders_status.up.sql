@@ -0,0 +1,19 @@
+ALTER TABLE public.orders
+  ADD COLUMN status              VARCHAR(20) NOT NULL DEFAULT 'pending',
+  ADD COLUMN create_at           TIMESTAMP   NOT NULL DEFAULT NOW(),
+  ADD COLUMN expires_at          TIMESTAMP,
+  ADD COLUMN awaiting_payment_at TIMESTAMP,
+  ADD COLUMN paid_at             TIMESTAMP,
+  ADD COLUMN expired_at          TIMESTAMP,
+  ADD COLUMN cancelled_at        TIMESTAMP,
+  ADD COLUMN refunded_at         TIMESTAMP;
+
+UPDATE public.orders
+SET status = 'paid', paid_at = COALESCE(update_at, NOW())
+WHERE ispaid IS TRUE;
+
+ALTER TABLE public.orders
+  ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'awaiting_payment', 'paid', 'expired', 'cancelled', 'refunded')),
+  DROP COLUMN ispaid;
+
+CREATE INDEX orders_status_expires_at_idx ON public.orders (status, expires_at);
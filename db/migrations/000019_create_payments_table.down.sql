@@ -0,0 +1,3 @@
+DROP TABLE public.payments;
+
+ALTER TABLE public.payment_method DROP COLUMN provider;
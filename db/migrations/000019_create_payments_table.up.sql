@@ -0,0 +1,17 @@
+ALTER TABLE public.payment_method ADD COLUMN provider VARCHAR(50) NOT NULL DEFAULT 'mock';
+
+CREATE TABLE public.payments (
+  id          INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_order    INTEGER      NOT NULL,
+  provider    VARCHAR(50)  NOT NULL,
+  reference   VARCHAR(255) NOT NULL UNIQUE,
+  amount      INTEGER      NOT NULL,
+  status      VARCHAR(20)  NOT NULL DEFAULT 'pending',
+  payment_url VARCHAR(255),
+  expires_at  TIMESTAMP,
+  create_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+  update_at   TIMESTAMP,
+  CONSTRAINT fk_id_order_payment FOREIGN KEY (id_order) REFERENCES public.orders (id)
+);
+
+CREATE INDEX payments_id_order_idx ON public.payments (id_order);
@@ -0,0 +1,22 @@
+DROP INDEX public.orderdetails_schedule_seat_active_uq;
+
+-- kursi yang sudah dilepas bentrok dengan unique constraint lama, barisnya dipindah ke arsip supaya riwayat
+-- order expired / dibatalkan tidak hilang. Migrasi up mengembalikannya.
+CREATE TABLE public.orderdetails_released_archive (
+  id_order    INTEGER      NOT NULL,
+  id_seat     VARCHAR(255) NOT NULL,
+  id_schedule INTEGER      NOT NULL,
+  released_at TIMESTAMP    NOT NULL,
+  CONSTRAINT orderdetails_released_archive_pk PRIMARY KEY (id_order, id_seat)
+);
+
+INSERT INTO public.orderdetails_released_archive (id_order, id_seat, id_schedule, released_at)
+SELECT id_order, id_seat, id_schedule, released_at
+FROM public.orderdetails
+WHERE released_at IS NOT NULL;
+
+DELETE FROM public.orderdetails WHERE released_at IS NOT NULL;
+
+ALTER TABLE public.orderdetails
+  ADD CONSTRAINT orderdetails_schedule_seat_uq UNIQUE (id_schedule, id_seat),
+  DROP COLUMN released_at;
@@ -0,0 +1,23 @@
+ALTER TABLE public.orderdetails ADD COLUMN released_at TIMESTAMP;
+
+ALTER TABLE public.orderdetails DROP CONSTRAINT orderdetails_schedule_seat_uq;
+
+-- kursi dari order yang expired / dibatalkan tidak lagi mengunci schedule
+CREATE UNIQUE INDEX orderdetails_schedule_seat_active_uq
+  ON public.orderdetails (id_schedule, id_seat)
+  WHERE released_at IS NULL;
+
+-- kembalikan baris yang diarsipkan migrasi down
+CREATE TABLE IF NOT EXISTS public.orderdetails_released_archive (
+  id_order    INTEGER      NOT NULL,
+  id_seat     VARCHAR(255) NOT NULL,
+  id_schedule INTEGER      NOT NULL,
+  released_at TIMESTAMP    NOT NULL,
+  CONSTRAINT orderdetails_released_archive_pk PRIMARY KEY (id_order, id_seat)
+);
+
+INSERT INTO public.orderdetails (id_order, id_seat, id_schedule, released_at)
+SELECT id_order, id_seat, id_schedule, released_at
+FROM public.orderdetails_released_archive;
+
+DROP TABLE public.orderdetails_released_archive;
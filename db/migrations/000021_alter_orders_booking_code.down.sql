@@ -0,0 +1,3 @@
+ALTER TABLE public.orders
+  DROP CONSTRAINT orders_booking_code_uq,
+  DROP COLUMN booking_code;
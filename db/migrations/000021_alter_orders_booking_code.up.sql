@@ -0,0 +1,10 @@
+ALTER TABLE public.orders ADD COLUMN booking_code VARCHAR(16);
+
+UPDATE public.orders
+SET booking_code = UPPER(SUBSTRING(MD5(RANDOM()::TEXT || id::TEXT) FROM 1 FOR 10))
+WHERE booking_code IS NULL;
+
+ALTER TABLE public.orders
+  ALTER COLUMN booking_code SET NOT NULL,
+  ADD CONSTRAINT orders_booking_code_uq UNIQUE (booking_code),
+  ALTER COLUMN qrcode TYPE VARCHAR(512);
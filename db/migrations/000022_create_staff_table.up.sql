@@ -0,0 +1,7 @@
+CREATE TABLE public.staff (
+  id        INTEGER PRIMARY KEY,
+  id_cinema INTEGER NOT NULL,
+  update_at TIMESTAMP,
+  CONSTRAINT fk_account_staff   FOREIGN KEY (id)        REFERENCES public.account (id),
+  CONSTRAINT fk_id_cinema_staff FOREIGN KEY (id_cinema) REFERENCES public.cinema (id)
+);
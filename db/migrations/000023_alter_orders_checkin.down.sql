@@ -0,0 +1,6 @@
+ALTER TABLE public.orderdetails DROP COLUMN checkin_at;
+
+ALTER TABLE public.orders
+  DROP CONSTRAINT fk_checkin_by_order,
+  DROP COLUMN checkin_by,
+  DROP COLUMN checkin_at;
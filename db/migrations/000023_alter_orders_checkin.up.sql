@@ -0,0 +1,6 @@
+ALTER TABLE public.orders
+  ADD COLUMN checkin_at TIMESTAMP,
+  ADD COLUMN checkin_by INTEGER,
+  ADD CONSTRAINT fk_checkin_by_order FOREIGN KEY (checkin_by) REFERENCES public.staff (id);
+
+ALTER TABLE public.orderdetails ADD COLUMN checkin_at TIMESTAMP;
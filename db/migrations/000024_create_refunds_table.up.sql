@@ -0,0 +1,18 @@
+CREATE TABLE public.refunds (
+  id           INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_order     INTEGER      NOT NULL,
+  id_payment   INTEGER,
+  amount       INTEGER      NOT NULL,
+  status       VARCHAR(20)  NOT NULL DEFAULT 'pending',
+  reference    VARCHAR(255),
+  reason       VARCHAR(255),
+  cancelled_by INTEGER      NOT NULL,
+  create_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+  update_at    TIMESTAMP,
+  CONSTRAINT refunds_status_check    CHECK (status IN ('pending', 'refunded', 'failed')),
+  CONSTRAINT fk_id_order_refund      FOREIGN KEY (id_order)     REFERENCES public.orders (id),
+  CONSTRAINT fk_id_payment_refund    FOREIGN KEY (id_payment)   REFERENCES public.payments (id),
+  CONSTRAINT fk_cancelled_by_refund  FOREIGN KEY (cancelled_by) REFERENCES public.account (id)
+);
+
+CREATE INDEX refunds_id_order_idx ON public.refunds (id_order);
@@ -0,0 +1 @@
+DROP TABLE public.point_ledger;
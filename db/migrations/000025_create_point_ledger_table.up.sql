@@ -0,0 +1,14 @@
+CREATE TABLE public.point_ledger (
+  id        INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_user   INTEGER     NOT NULL,
+  id_order  INTEGER,
+  points    INTEGER     NOT NULL,
+  reason    VARCHAR(20) NOT NULL,
+  create_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+  CONSTRAINT point_ledger_reason_check CHECK (reason IN ('earn', 'redeem', 'reverse', 'adjust')),
+  CONSTRAINT fk_id_user_point_ledger   FOREIGN KEY (id_user)  REFERENCES public.users (id),
+  CONSTRAINT fk_id_order_point_ledger  FOREIGN KEY (id_order) REFERENCES public.orders (id)
+);
+
+CREATE INDEX point_ledger_id_user_idx ON public.point_ledger (id_user);
+CREATE INDEX point_ledger_id_order_idx ON public.point_ledger (id_order);
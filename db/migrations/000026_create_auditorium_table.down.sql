@@ -0,0 +1,21 @@
+DROP TRIGGER orderdetails_check_seat_trg ON public.orderdetails;
+DROP FUNCTION public.orderdetails_check_seat();
+
+CREATE TABLE public.seat (
+  id VARCHAR(255) PRIMARY KEY
+);
+
+INSERT INTO public.seat (id)
+SELECT chr(64 + r) || c
+FROM generate_series(1, 8) r, generate_series(1, 14) c;
+
+ALTER TABLE public.orderdetails
+  ADD CONSTRAINT fk_id_seat_details FOREIGN KEY (id_seat) REFERENCES public.seat (id);
+
+ALTER TABLE public.schedule
+  DROP CONSTRAINT fk_id_auditorium_schedule,
+  DROP COLUMN id_auditorium;
+
+DROP TABLE public.auditorium_seat;
+
+DROP TABLE public.auditorium;
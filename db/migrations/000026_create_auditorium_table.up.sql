@@ -0,0 +1,66 @@
+CREATE TABLE public.auditorium (
+  id        INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_cinema INTEGER      NOT NULL,
+  name      VARCHAR(255) NOT NULL,
+  layout    JSONB        NOT NULL,
+  update_at TIMESTAMP,
+  delete_at TIMESTAMP,
+  CONSTRAINT fk_id_cinema_auditorium FOREIGN KEY (id_cinema) REFERENCES public.cinema (id),
+  CONSTRAINT auditorium_cinema_name_uq UNIQUE (id_cinema, name)
+);
+
+CREATE TABLE public.auditorium_seat (
+  id_auditorium INTEGER     NOT NULL,
+  id_seat       VARCHAR(10) NOT NULL,
+  row_label     VARCHAR(2)  NOT NULL,
+  col_number    INTEGER     NOT NULL,
+  disabled      BOOLEAN     NOT NULL DEFAULT FALSE,
+  CONSTRAINT auditorium_seat_pk PRIMARY KEY (id_auditorium, id_seat),
+  CONSTRAINT fk_id_auditorium_seat FOREIGN KEY (id_auditorium) REFERENCES public.auditorium (id)
+);
+
+-- setiap cinema yang sudah ada mendapat satu studio dengan denah lama (A-H x 14)
+INSERT INTO public.auditorium (id_cinema, name, layout)
+SELECT id, 'Studio 1', '{"rows": 8, "columns": 14, "aisles": [7], "gaps": [], "disabled": []}'
+FROM public.cinema;
+
+INSERT INTO public.auditorium_seat (id_auditorium, id_seat, row_label, col_number)
+SELECT a.id, chr(64 + r) || c, chr(64 + r), c
+FROM public.auditorium a, generate_series(1, 8) r, generate_series(1, 14) c;
+
+ALTER TABLE public.schedule ADD COLUMN id_auditorium INTEGER;
+
+UPDATE public.schedule s
+SET id_auditorium = a.id
+FROM public.auditorium a
+WHERE a.id_cinema = s.id_cinema;
+
+ALTER TABLE public.schedule
+  ALTER COLUMN id_auditorium SET NOT NULL,
+  ADD CONSTRAINT fk_id_auditorium_schedule FOREIGN KEY (id_auditorium) REFERENCES public.auditorium (id);
+
+-- kursi sekarang milik studio, id kursi yang sama bisa ada di banyak studio sehingga FK tunggal ke seat tidak lagi tepat.
+-- FK komposit ke auditorium_seat juga tidak dipakai karena perubahan denah boleh menghapus kursi yang pernah terjual
+-- di jadwal lampau. Trigger memastikan setiap kursi yang baru dipesan atau ditukar ada di studio schedule-nya.
+ALTER TABLE public.orderdetails DROP CONSTRAINT fk_id_seat_details;
+
+CREATE FUNCTION public.orderdetails_check_seat() RETURNS TRIGGER AS $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM public.schedule s
+    JOIN public.auditorium_seat a ON a.id_auditorium = s.id_auditorium
+    WHERE s.id = NEW.id_schedule AND a.id_seat = NEW.id_seat
+  ) THEN
+    RAISE EXCEPTION 'seat % does not exist in the auditorium of schedule %', NEW.id_seat, NEW.id_schedule
+      USING ERRCODE = 'foreign_key_violation';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orderdetails_check_seat_trg
+  BEFORE INSERT OR UPDATE OF id_seat, id_schedule ON public.orderdetails
+  FOR EACH ROW EXECUTE FUNCTION public.orderdetails_check_seat();
+
+DROP TABLE public.seat;
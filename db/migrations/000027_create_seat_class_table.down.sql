@@ -0,0 +1,11 @@
+ALTER TABLE public.orderdetails
+  DROP CONSTRAINT fk_id_seat_class_details,
+  DROP COLUMN price,
+  DROP COLUMN id_seat_class;
+
+ALTER TABLE public.auditorium_seat
+  DROP CONSTRAINT fk_id_seat_class_auditorium_seat,
+  DROP COLUMN pair_seat,
+  DROP COLUMN id_seat_class;
+
+DROP TABLE public.seat_class;
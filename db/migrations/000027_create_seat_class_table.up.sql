@@ -0,0 +1,42 @@
+CREATE TABLE public.seat_class (
+  id         INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  code       VARCHAR(20)  NOT NULL UNIQUE,
+  name       VARCHAR(255) NOT NULL,
+  price      INTEGER,
+  modifier   INTEGER      NOT NULL DEFAULT 0,
+  pairs_only BOOLEAN      NOT NULL DEFAULT FALSE,
+  update_at  TIMESTAMP,
+  CONSTRAINT seat_class_price_check CHECK (price IS NULL OR price >= 0)
+);
+
+-- price diisi berarti harga tetap, kosong berarti cinema.price + modifier
+INSERT INTO public.seat_class (code, name, price, modifier, pairs_only) VALUES
+  ('regular', 'Regular', NULL, 0, FALSE),
+  ('premium', 'Premium', NULL, 10, FALSE),
+  ('couple', 'Couple Sofa', NULL, 20, TRUE);
+
+ALTER TABLE public.auditorium_seat
+  ADD COLUMN id_seat_class INTEGER,
+  ADD COLUMN pair_seat     VARCHAR(10);
+
+UPDATE public.auditorium_seat SET id_seat_class = (SELECT id FROM public.seat_class WHERE code = 'regular');
+
+ALTER TABLE public.auditorium_seat
+  ALTER COLUMN id_seat_class SET NOT NULL,
+  ADD CONSTRAINT fk_id_seat_class_auditorium_seat FOREIGN KEY (id_seat_class) REFERENCES public.seat_class (id);
+
+ALTER TABLE public.orderdetails
+  ADD COLUMN id_seat_class INTEGER,
+  ADD COLUMN price         INTEGER;
+
+UPDATE public.orderdetails od
+SET id_seat_class = (SELECT id FROM public.seat_class WHERE code = 'regular'),
+    price = c.price
+FROM public.schedule s
+JOIN public.cinema c ON c.id = s.id_cinema
+WHERE s.id = od.id_schedule;
+
+ALTER TABLE public.orderdetails
+  ALTER COLUMN id_seat_class SET NOT NULL,
+  ALTER COLUMN price SET NOT NULL,
+  ADD CONSTRAINT fk_id_seat_class_details FOREIGN KEY (id_seat_class) REFERENCES public.seat_class (id);
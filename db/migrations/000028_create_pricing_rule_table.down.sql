@@ -0,0 +1,3 @@
+DROP TABLE public.pricing_rule;
+
+DROP TABLE public.holiday;
@@ -0,0 +1,35 @@
+CREATE TABLE public.holiday (
+  id        INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  date      DATE         NOT NULL UNIQUE,
+  name      VARCHAR(255) NOT NULL,
+  update_at TIMESTAMP
+);
+
+-- Kondisi yang kosong (NULL) berlaku untuk semua schedule.
+-- days memakai ISO day of week (1 = Senin ... 7 = Minggu), start_time > end_time berarti melewati tengah malam.
+-- price diisi berarti harga dasar tetap, kosong berarti cinema.price + modifier.
+CREATE TABLE public.pricing_rule (
+  id         INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name       VARCHAR(255) NOT NULL,
+  id_cinema  INTEGER,
+  days       SMALLINT[],
+  start_time TIME,
+  end_time   TIME,
+  holiday    BOOLEAN,
+  start_date DATE,
+  end_date   DATE,
+  price      INTEGER,
+  modifier   INTEGER      NOT NULL DEFAULT 0,
+  priority   INTEGER      NOT NULL DEFAULT 0,
+  active     BOOLEAN      NOT NULL DEFAULT TRUE,
+  update_at  TIMESTAMP,
+  delete_at  TIMESTAMP,
+  CONSTRAINT fk_id_cinema_pricing_rule FOREIGN KEY (id_cinema) REFERENCES public.cinema (id),
+  CONSTRAINT pricing_rule_price_check CHECK (price IS NULL OR price >= 0),
+  CONSTRAINT pricing_rule_time_check CHECK ((start_time IS NULL) = (end_time IS NULL)),
+  CONSTRAINT pricing_rule_date_check CHECK (start_date IS NULL OR end_date IS NULL OR start_date <= end_date)
+);
+
+INSERT INTO public.pricing_rule (name, days, holiday, modifier, priority) VALUES
+  ('Weekend', '{6,7}', NULL, 10, 10),
+  ('Holiday', NULL, TRUE, 15, 20);
@@ -0,0 +1,11 @@
+ALTER TABLE public.orders DROP COLUMN discount;
+
+DROP TABLE public.promo_redemption;
+
+DROP TABLE public.promo_code_payment_method;
+
+DROP TABLE public.promo_code_cinema;
+
+DROP TABLE public.promo_code_movie;
+
+DROP TABLE public.promo_code;
@@ -0,0 +1,63 @@
+CREATE TABLE public.promo_code (
+  id             INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  code           VARCHAR(50)  NOT NULL UNIQUE,
+  description    VARCHAR(255),
+  discount_type  VARCHAR(10)  NOT NULL,
+  discount_value INTEGER      NOT NULL,
+  max_discount   INTEGER,
+  min_spend      INTEGER      NOT NULL DEFAULT 0,
+  start_at       TIMESTAMP    NOT NULL,
+  end_at         TIMESTAMP    NOT NULL,
+  usage_limit    INTEGER,
+  per_user_limit INTEGER,
+  active         BOOLEAN      NOT NULL DEFAULT TRUE,
+  create_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+  update_at      TIMESTAMP,
+  delete_at      TIMESTAMP,
+  CONSTRAINT promo_code_type_check CHECK (discount_type IN ('percent', 'fixed')),
+  CONSTRAINT promo_code_value_check CHECK (discount_value > 0 AND (discount_type <> 'percent' OR discount_value <= 100)),
+  CONSTRAINT promo_code_window_check CHECK (start_at < end_at)
+);
+
+-- Promo tanpa baris di tabel pembatas berlaku untuk semua movie / cinema / payment method
+CREATE TABLE public.promo_code_movie (
+  id_promo INTEGER NOT NULL,
+  id_movie INTEGER NOT NULL,
+  CONSTRAINT promo_code_movie_pk PRIMARY KEY (id_promo, id_movie),
+  CONSTRAINT fk_id_promo_movie FOREIGN KEY (id_promo) REFERENCES public.promo_code (id),
+  CONSTRAINT fk_id_movie_promo FOREIGN KEY (id_movie) REFERENCES public.movies (id)
+);
+
+CREATE TABLE public.promo_code_cinema (
+  id_promo  INTEGER NOT NULL,
+  id_cinema INTEGER NOT NULL,
+  CONSTRAINT promo_code_cinema_pk PRIMARY KEY (id_promo, id_cinema),
+  CONSTRAINT fk_id_promo_cinema FOREIGN KEY (id_promo) REFERENCES public.promo_code (id),
+  CONSTRAINT fk_id_cinema_promo FOREIGN KEY (id_cinema) REFERENCES public.cinema (id)
+);
+
+CREATE TABLE public.promo_code_payment_method (
+  id_promo          INTEGER NOT NULL,
+  id_payment_method INTEGER NOT NULL,
+  CONSTRAINT promo_code_payment_method_pk PRIMARY KEY (id_promo, id_payment_method),
+  CONSTRAINT fk_id_promo_payment_method FOREIGN KEY (id_promo) REFERENCES public.promo_code (id),
+  CONSTRAINT fk_id_payment_method_promo FOREIGN KEY (id_payment_method) REFERENCES public.payment_method (id)
+);
+
+-- released_at diisi ketika order expired / dibatalkan sehingga kuota promo kembali
+CREATE TABLE public.promo_redemption (
+  id          INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_promo    INTEGER   NOT NULL,
+  id_order    INTEGER   NOT NULL UNIQUE,
+  id_user     INTEGER,
+  discount    INTEGER   NOT NULL,
+  create_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+  released_at TIMESTAMP,
+  CONSTRAINT fk_id_promo_redemption FOREIGN KEY (id_promo) REFERENCES public.promo_code (id),
+  CONSTRAINT fk_id_order_redemption FOREIGN KEY (id_order) REFERENCES public.orders (id),
+  CONSTRAINT fk_id_user_redemption  FOREIGN KEY (id_user)  REFERENCES public.users (id)
+);
+
+CREATE INDEX promo_redemption_active_idx ON public.promo_redemption (id_promo, id_user) WHERE released_at IS NULL;
+
+ALTER TABLE public.orders ADD COLUMN discount INTEGER NOT NULL DEFAULT 0;
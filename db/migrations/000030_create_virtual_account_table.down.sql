@@ -0,0 +1,9 @@
+ALTER TABLE public.users ADD COLUMN virtual_account VARCHAR(255);
+
+DROP TABLE public.virtual_account;
+
+DROP SEQUENCE public.virtual_account_seq;
+
+UPDATE public.payment_method SET provider = 'mock' WHERE provider = 'va';
+
+ALTER TABLE public.payment_method DROP COLUMN bank_code;
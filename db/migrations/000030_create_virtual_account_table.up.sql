@@ -0,0 +1,30 @@
+-- Kode bank dipakai sebagai prefix nomor virtual account, hanya diisi untuk payment method transfer bank
+ALTER TABLE public.payment_method ADD COLUMN bank_code VARCHAR(4);
+
+UPDATE public.payment_method SET provider = 'va', bank_code = '002' WHERE name = 'BRI';
+UPDATE public.payment_method SET provider = 'va', bank_code = '014' WHERE name = 'BCA';
+
+CREATE SEQUENCE public.virtual_account_seq;
+
+-- Nomor VA diterbitkan server per order: kode bank + nomor urut + check digit (Luhn)
+CREATE TABLE public.virtual_account (
+  id                INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  number            VARCHAR(20)  NOT NULL UNIQUE,
+  id_payment_method INTEGER      NOT NULL,
+  id_order          INTEGER      NOT NULL,
+  amount            INTEGER      NOT NULL,
+  status            VARCHAR(20)  NOT NULL DEFAULT 'pending',
+  expires_at        TIMESTAMP,
+  paid_at           TIMESTAMP,
+  create_at         TIMESTAMP    NOT NULL DEFAULT NOW(),
+  update_at         TIMESTAMP,
+  CONSTRAINT virtual_account_status_check CHECK (status IN ('pending', 'paid', 'expired', 'refunded')),
+  CONSTRAINT virtual_account_amount_check CHECK (amount > 0),
+  CONSTRAINT fk_id_payment_method_va FOREIGN KEY (id_payment_method) REFERENCES public.payment_method (id),
+  CONSTRAINT fk_id_order_va FOREIGN KEY (id_order) REFERENCES public.orders (id)
+);
+
+CREATE INDEX virtual_account_id_order_idx ON public.virtual_account (id_order);
+
+-- Nomor VA tidak lagi diisi bebas oleh user lewat PATCH /user
+ALTER TABLE public.users DROP COLUMN virtual_account;
@@ -0,0 +1 @@
+ALTER TABLE public.cinema DROP COLUMN no_orphan_seat;
@@ -0,0 +1 @@
+ALTER TABLE public.cinema ADD COLUMN no_orphan_seat BOOLEAN NOT NULL DEFAULT FALSE;
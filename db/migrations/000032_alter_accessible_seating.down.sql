@@ -0,0 +1,3 @@
+ALTER TABLE public.orders DROP COLUMN accessibility;
+
+ALTER TABLE public.auditorium_seat DROP COLUMN access;
@@ -0,0 +1,4 @@
+ALTER TABLE public.auditorium_seat
+  ADD COLUMN access VARCHAR(20) CHECK (access IN ('wheelchair', 'companion'));
+
+ALTER TABLE public.orders ADD COLUMN accessibility BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+DROP TABLE public.seat_change;
@@ -0,0 +1,28 @@
+-- tukar kursi ke kursi yang lebih mahal pada order lunas berstatus pending sampai charge selisihnya dibayar,
+-- kelas dan harga kursi baru disimpan supaya penukaran bisa diterapkan saat webhook pembayaran masuk
+CREATE TABLE public.seat_change (
+  id               INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_order         INTEGER      NOT NULL,
+  from_seats       VARCHAR(10)[] NOT NULL,
+  to_seats         VARCHAR(10)[] NOT NULL,
+  to_seat_classes  INTEGER[],
+  to_prices        INTEGER[],
+  price_difference INTEGER      NOT NULL,
+  status           VARCHAR(20)  NOT NULL DEFAULT 'applied',
+  id_payment       INTEGER,
+  id_refund        INTEGER,
+  changed_by       INTEGER      NOT NULL,
+  expires_at       TIMESTAMP,
+  create_at        TIMESTAMP    NOT NULL DEFAULT NOW(),
+  update_at        TIMESTAMP,
+  CONSTRAINT seat_change_status_check  CHECK (status IN ('pending', 'applied', 'expired', 'failed')),
+  CONSTRAINT fk_id_order_seat_change   FOREIGN KEY (id_order)   REFERENCES public.orders (id),
+  CONSTRAINT fk_id_payment_seat_change FOREIGN KEY (id_payment) REFERENCES public.payments (id),
+  CONSTRAINT fk_id_refund_seat_change  FOREIGN KEY (id_refund)  REFERENCES public.refunds (id),
+  CONSTRAINT fk_changed_by_seat_change FOREIGN KEY (changed_by) REFERENCES public.account (id)
+);
+
+CREATE INDEX seat_change_id_order_idx ON public.seat_change (id_order);
+
+-- satu order hanya boleh punya satu tukar kursi yang menunggu pembayaran
+CREATE UNIQUE INDEX seat_change_pending_idx ON public.seat_change (id_order) WHERE status = 'pending';
@@ -0,0 +1 @@
+DROP TABLE public.order_transfer;
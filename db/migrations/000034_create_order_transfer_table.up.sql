@@ -0,0 +1,18 @@
+CREATE TABLE public.order_transfer (
+  id           INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_order     INTEGER     NOT NULL,
+  from_user    INTEGER     NOT NULL,
+  to_user      INTEGER     NOT NULL,
+  status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+  create_at    TIMESTAMP   NOT NULL DEFAULT NOW(),
+  responded_at TIMESTAMP,
+  CONSTRAINT order_transfer_status_check CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+  CONSTRAINT fk_id_order_transfer        FOREIGN KEY (id_order)  REFERENCES public.orders (id),
+  CONSTRAINT fk_from_user_transfer       FOREIGN KEY (from_user) REFERENCES public.users (id),
+  CONSTRAINT fk_to_user_transfer         FOREIGN KEY (to_user)   REFERENCES public.users (id)
+);
+
+-- satu order hanya boleh punya satu transfer yang menunggu jawaban
+CREATE UNIQUE INDEX order_transfer_pending_idx ON public.order_transfer (id_order) WHERE status = 'pending';
+CREATE INDEX order_transfer_to_user_idx ON public.order_transfer (to_user);
+CREATE INDEX order_transfer_from_user_idx ON public.order_transfer (from_user);
@@ -0,0 +1,19 @@
+CREATE TABLE public.waitlist (
+  id               INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_schedule      INTEGER       NOT NULL,
+  id_user          INTEGER       NOT NULL,
+  seat_count       INTEGER       NOT NULL,
+  status           VARCHAR(20)   NOT NULL DEFAULT 'waiting',
+  seats            VARCHAR(10)[],
+  offer_expires_at TIMESTAMP,
+  create_at        TIMESTAMP     NOT NULL DEFAULT NOW(),
+  update_at        TIMESTAMP,
+  CONSTRAINT waitlist_seat_count_check CHECK (seat_count > 0),
+  CONSTRAINT waitlist_status_check     CHECK (status IN ('waiting', 'offered', 'booked', 'expired', 'cancelled')),
+  CONSTRAINT fk_id_schedule_waitlist   FOREIGN KEY (id_schedule) REFERENCES public.schedule (id),
+  CONSTRAINT fk_id_user_waitlist       FOREIGN KEY (id_user)     REFERENCES public.users (id)
+);
+
+-- satu user hanya boleh punya satu antrean aktif per schedule
+CREATE UNIQUE INDEX waitlist_active_idx ON public.waitlist (id_schedule, id_user) WHERE status IN ('waiting', 'offered');
+CREATE INDEX waitlist_id_user_idx ON public.waitlist (id_user);
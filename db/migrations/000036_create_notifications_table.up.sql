@@ -0,0 +1,13 @@
+CREATE TABLE public.notifications (
+  id          INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  id_user     INTEGER      NOT NULL,
+  type        VARCHAR(50)  NOT NULL,
+  message     VARCHAR(255) NOT NULL,
+  id_schedule INTEGER,
+  read_at     TIMESTAMP,
+  create_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_id_user_notification     FOREIGN KEY (id_user)     REFERENCES public.users (id),
+  CONSTRAINT fk_id_schedule_notification FOREIGN KEY (id_schedule) REFERENCES public.schedule (id)
+);
+
+CREATE INDEX notifications_id_user_idx ON public.notifications (id_user, id DESC);
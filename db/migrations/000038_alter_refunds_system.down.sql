@@ -0,0 +1,8 @@
+-- refund sistem dari order user dicatat atas nama pemilik order. Refund sistem order tamu tidak punya akun,
+-- constraint gagal dipasang selama masih ada baris seperti itu supaya riwayat refund tidak terhapus.
+UPDATE public.refunds r
+SET cancelled_by = o.id_user
+FROM public.orders o
+WHERE o.id = r.id_order AND r.cancelled_by IS NULL AND o.id_user IS NOT NULL;
+
+ALTER TABLE public.refunds ALTER COLUMN cancelled_by SET NOT NULL;
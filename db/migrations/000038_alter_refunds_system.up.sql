@@ -0,0 +1,2 @@
+-- refund untuk pembayaran yang masuk setelah order expired / dibatalkan dibuat sistem, tidak ada akun pembatalnya
+ALTER TABLE public.refunds ALTER COLUMN cancelled_by DROP NOT NULL;
@@ -4,4 +4,6 @@ INSERT INTO public.account (id,email,"password","role",update_at) VALUES
 	 (3,'user2@mail.com','User!23','user',NULL),
 	 (4,'user3@mail.com','User!23','user',NULL),
 	 (5,'user4@mail.com','User!23','user',NULL),
-	 (6,'user5@mail.com','User!23','user',NULL);
+	 (6,'user5@mail.com','User!23','user',NULL),
+	 (7,'staff1@mail.com','Staff!23','staff',NULL),
+	 (8,'staff2@mail.com','Staff!23','staff',NULL);
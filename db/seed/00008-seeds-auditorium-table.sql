@@ -0,0 +1,10 @@
+INSERT INTO public.auditorium (id,id_cinema,"name",layout,update_at,delete_at) VALUES
+	 (1,1,'Studio 1','{"rows": 8, "columns": 14, "aisles": [7], "gaps": [], "disabled": []}',NULL,NULL),
+	 (2,2,'Studio 1','{"rows": 8, "columns": 14, "aisles": [7], "gaps": [], "disabled": []}',NULL,NULL),
+	 (3,3,'Studio 1','{"rows": 8, "columns": 14, "aisles": [7], "gaps": [], "disabled": []}',NULL,NULL),
+	 (4,4,'Studio 1','{"rows": 8, "columns": 14, "aisles": [7], "gaps": [], "disabled": []}',NULL,NULL),
+	 (5,5,'Studio 1','{"rows": 8, "columns": 14, "aisles": [7], "gaps": [], "disabled": []}',NULL,NULL);
+INSERT INTO public.auditorium_seat (id_auditorium,id_seat,row_label,col_number,disabled,id_seat_class,pair_seat)
+SELECT a.id, chr(64 + r) || c, chr(64 + r), c, false, sc.id, NULL
+FROM public.auditorium a, public.seat_class sc, generate_series(1, 8) r, generate_series(1, 14) c
+WHERE sc.code = 'regular';
@@ -1,124 +0,0 @@
-INSERT INTO public.seat (id) VALUES
-	 ('A1'),
-	 ('A2'),
-	 ('A3'),
-	 ('A4'),
-	 ('A5'),
-	 ('A6'),
-	 ('A7'),
-	 ('A8'),
-	 ('A9'),
-	 ('A10');
-INSERT INTO public.seat (id) VALUES
-	 ('A11'),
-	 ('A12'),
-	 ('A13'),
-	 ('A14'),
-	 ('B1'),
-	 ('B2'),
-	 ('B3'),
-	 ('B4'),
-	 ('B5'),
-	 ('B6');
-INSERT INTO public.seat (id) VALUES
-	 ('B7'),
-	 ('B8'),
-	 ('B9'),
-	 ('B10'),
-	 ('B11'),
-	 ('B12'),
-	 ('B13'),
-	 ('B14'),
-	 ('C1'),
-	 ('C2');
-INSERT INTO public.seat (id) VALUES
-	 ('C3'),
-	 ('C4'),
-	 ('C5'),
-	 ('C6'),
-	 ('C7'),
-	 ('C8'),
-	 ('C9'),
-	 ('C10'),
-	 ('C11'),
-	 ('C12');
-INSERT INTO public.seat (id) VALUES
-	 ('C13'),
-	 ('C14'),
-	 ('D1'),
-	 ('D2'),
-	 ('D3'),
-	 ('D4'),
-	 ('D5'),
-	 ('D6'),
-	 ('D7'),
-	 ('D8');
-INSERT INTO public.seat (id) VALUES
-	 ('D9'),
-	 ('D10'),
-	 ('D11'),
-	 ('D12'),
-	 ('D13'),
-	 ('D14'),
-	 ('E1'),
-	 ('E2'),
-	 ('E3'),
-	 ('E4');
-INSERT INTO public.seat (id) VALUES
-	 ('E5'),
-	 ('E6'),
-	 ('E7'),
-	 ('E8'),
-	 ('E9'),
-	 ('E10'),
-	 ('E11'),
-	 ('E12'),
-	 ('E13'),
-	 ('E14');
-INSERT INTO public.seat (id) VALUES
-	 ('F1'),
-	 ('F2'),
-	 ('F3'),
-	 ('F4'),
-	 ('F5'),
-	 ('F6'),
-	 ('F7'),
-	 ('F8'),
-	 ('F9'),
-	 ('F10');
-INSERT INTO public.seat (id) VALUES
-	 ('F11'),
-	 ('F12'),
-	 ('F13'),
-	 ('F14'),
-	 ('G1'),
-	 ('G2'),
-	 ('G3'),
-	 ('G4'),
-	 ('G5'),
-	 ('G6');
-INSERT INTO public.seat (id) VALUES
-	 ('G7'),
-	 ('G8'),
-	 ('G9'),
-	 ('G10'),
-	 ('G11'),
-	 ('G12'),
-	 ('G13'),
-	 ('G14'),
-	 ('H1'),
-	 ('H2');
-INSERT INTO public.seat (id) VALUES
-	 ('H3'),
-	 ('H4'),
-	 ('H5'),
-	 ('H6'),
-	 ('H7'),
-	 ('H8'),
-	 ('H9'),
-	 ('H10'),
-	 ('H11'),
-	 ('H12');
-INSERT INTO public.seat (id) VALUES
-	 ('H13'),
-	 ('H14');
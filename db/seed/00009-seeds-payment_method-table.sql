@@ -1,9 +1,9 @@
-INSERT INTO public.payment_method (id,logo,name) VALUES
-	 (1,'ovo.png','OVO'),
-	 (2,'bri.png','BRI'),
-	 (3,'bca.png','BCA'),
-	 (4,'dana.png','DANA'),
-	 (5,'paypal.png','Paypal'),
-	 (6,'gopay.png','Gopay'),
-	 (7,'visa.png','Visa'),
-	 (8,'google_pay.png','Google Pay');
+INSERT INTO public.payment_method (id,logo,name,provider,bank_code) VALUES
+	 (1,'ovo.png','OVO','mock',NULL),
+	 (2,'bri.png','BRI','va','002'),
+	 (3,'bca.png','BCA','va','014'),
+	 (4,'dana.png','DANA','mock',NULL),
+	 (5,'paypal.png','Paypal','mock',NULL),
+	 (6,'gopay.png','Gopay','mock',NULL),
+	 (7,'visa.png','Visa','mock',NULL),
+	 (8,'google_pay.png','Google Pay','mock',NULL);
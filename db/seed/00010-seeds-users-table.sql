@@ -1,5 +1,5 @@
-INSERT INTO public.users (id,profileimg,firstname,lastname,phone,point,virtual_account,update_at) VALUES
-	 (2,'profile2.png','Kamidin','Suryono','81209099090',10,'00001081209099090',NULL),
-	 (3,'profile3.png','Harto','Wibowo','08121919191',20,'00001008121919191',NULL),
-	 (4,'profile4.png','Aurora','Permata','08122929292',30,'00001008122929292',NULL),
-	 (5,'profile5.png','Dewi','Utami','08123939393',40,'00001008123939393',NULL);
+INSERT INTO public.users (id,profileimg,firstname,lastname,phone,point,update_at) VALUES
+	 (2,'profile2.png','Kamidin','Suryono','81209099090',10,NULL),
+	 (3,'profile3.png','Harto','Wibowo','08121919191',20,NULL),
+	 (4,'profile4.png','Aurora','Permata','08122929292',30,NULL),
+	 (5,'profile5.png','Dewi','Utami','08123939393',40,NULL);
@@ -0,0 +1,3 @@
+INSERT INTO public.staff (id,id_cinema,update_at) VALUES
+	 (7,1,NULL),
+	 (8,2,NULL);
@@ -0,0 +1,6 @@
+INSERT INTO public.holiday ("date",name,update_at) VALUES
+	 ('2026-01-01','Tahun Baru Masehi',NULL),
+	 ('2026-03-20','Idul Fitri',NULL),
+	 ('2026-03-21','Idul Fitri',NULL),
+	 ('2026-08-17','Hari Kemerdekaan',NULL),
+	 ('2026-12-25','Hari Natal',NULL);
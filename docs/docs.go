@@ -431,9 +431,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/genres": {
-            "get": {
-                "description": "Get all movie genres",
+        "/checkin": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Validate a scanned QR code or booking code and mark the tickets as used. The order must be paid and scheduled today at the staff's cinema.",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,14 +446,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Genres"
+                    "Checkin"
+                ],
+                "summary": "Check in a ticket",
+                "parameters": [
+                    {
+                        "description": "Scanned code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckinRequest"
+                        }
+                    }
                 ],
-                "summary": "Get list of genres",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GenreResponse"
+                            "$ref": "#/definitions/models.ResponseCheckin"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ticket code",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff is not assigned to a cinema",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already used, not paid, not today, or for another cinema",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -460,14 +506,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/movies/": {
+        "/genres": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieve movies filtered by title, genres, and page number (supports Redis caching)",
+                "description": "Get all movie genres",
                 "consumes": [
                     "application/json"
                 ],
@@ -475,40 +516,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
-                ],
-                "summary": "Get filtered movies",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by movie title",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by genres, comma-separated",
-                        "name": "genres",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number for pagination",
-                        "name": "page",
-                        "in": "query"
-                    }
+                    "Genres"
                 ],
+                "summary": "Get list of genres",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseMovies"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.GenreResponse"
                         }
                     },
                     "500": {
@@ -520,29 +535,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/movies/popular": {
+        "/master/auditoriums/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of popular movies (cached in Redis for 10 minutes)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve an auditorium with its seat layout",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Master"
+                ],
+                "summary": "Get an auditorium",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Auditorium ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get popular movies",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseMovies"
+                            "$ref": "#/definitions/models.ResponseAuditorium"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
@@ -558,16 +591,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/movies/upcoming": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of upcoming movies (cached in Redis for 10 minutes)",
+                "description": "Rename an auditorium or change its layout. Removing seats that are sold for upcoming schedules is rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -575,14 +606,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Master"
+                ],
+                "summary": "Update an auditorium",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Auditorium ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Auditorium",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AuditoriumRequest"
+                        }
+                    }
                 ],
-                "summary": "Get upcoming movies",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseMovies"
+                            "$ref": "#/definitions/models.ResponseAuditorium"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
@@ -591,6 +652,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Seats sold on upcoming schedules",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -598,30 +665,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/movies/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information for a single movie by ID (cached in Redis for 10 minutes)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Soft delete an auditorium that has no upcoming schedules",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Master"
                 ],
-                "summary": "Get movie details",
+                "summary": "Delete an auditorium",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Auditorium ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -631,7 +693,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseMovieDetail"
+                            "$ref": "#/definitions/models.ResponseMessage"
                         }
                     },
                     "400": {
@@ -640,12 +702,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Auditorium has upcoming schedules",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -655,40 +729,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/order": {
-            "post": {
+        "/master/cinemas/{id}/auditoriums": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new order with seats and associate it with the logged-in user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve all auditoriums of a cinema with their seat layout",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Master"
                 ],
-                "summary": "Create a new order",
+                "summary": "Get auditoriums of a cinema",
                 "parameters": [
                     {
-                        "description": "Order request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.OrderRequest"
-                        }
+                        "type": "integer",
+                        "description": "Cinema ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseOrders"
+                            "$ref": "#/definitions/models.ResponseAuditoriums"
                         }
                     },
                     "400": {
@@ -710,16 +779,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/schedule/seat/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve all sold seats for a specific schedule",
+                "description": "Add an auditorium to a cinema. Seats are generated from the layout: rows are labelled A, B, C... and columns numbered from 1, gaps have no seat, disabled seats are never sold and class areas assign seat classes (couple seats are paired from the left).",
                 "consumes": [
                     "application/json"
                 ],
@@ -727,23 +794,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Master"
                 ],
-                "summary": "Get sold seats",
+                "summary": "Create an auditorium",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
+                        "description": "Cinema ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Auditorium",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AuditoriumRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSeats"
+                            "$ref": "#/definitions/models.ResponseAuditorium"
                         }
                     },
                     "400": {
@@ -758,6 +834,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Cinema not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Auditorium name already used",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -767,14 +855,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/schedule/{id}": {
-            "get": {
+        "/master/cinemas/{id}/seat-rules": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get all schedules for a specific movie by its ID",
+                "description": "Toggle the seat selection rules of a cinema. With no_orphan_seat enabled, holds and orders that would leave a single empty seat between occupied seats, aisles or gaps are rejected with alternative seats.",
                 "consumes": [
                     "application/json"
                 ],
@@ -782,23 +870,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Schedules"
+                    "Master"
                 ],
-                "summary": "Get movie schedules",
+                "summary": "Update cinema seat rules",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Cinema ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Seat rules",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CinemaSeatRulesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSchedule"
+                            "$ref": "#/definitions/models.ResponseCinemaSeatRules"
                         }
                     },
                     "400": {
@@ -813,6 +910,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Cinema not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -822,26 +925,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
+        "/master/holidays": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Mengambil data profil user yang sedang login",
+                "description": "Retrieve the holiday calendar used by holiday pricing rules",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Master"
                 ],
-                "summary": "Get user profile",
+                "summary": "Get holidays",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseUserProfile"
+                            "$ref": "#/definitions/models.ResponseHolidays"
                         }
                     },
                     "401": {
@@ -857,57 +960,98 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/": {
-            "patch": {
-                "security": [
+            },
+            "post": {
+                "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Mengupdate profil user termasuk upload profile image",
+                "description": "Register a date in the holiday calendar",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Master"
                 ],
-                "summary": "Update user profile",
+                "summary": "Add a holiday",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Profile Image",
-                        "name": "profileimg",
-                        "in": "formData"
+                        "description": "Holiday",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HolidayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseHoliday"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "First Name",
-                        "name": "firstname",
-                        "in": "formData"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Last Name",
-                        "name": "lastname",
-                        "in": "formData"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Date already registered",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/master/holidays/{id}": {
+            "delete": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Phone",
-                        "name": "phone",
-                        "in": "formData"
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a date from the holiday calendar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Delete a holiday",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseUpdateProfile"
+                            "$ref": "#/definitions/models.ResponseMessage"
                         }
                     },
                     "400": {
@@ -922,6 +1066,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -931,26 +1081,207 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/history": {
+        "/master/pricing-rules": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Mengambil riwayat order user yang sedang login",
+                "description": "Retrieve all pricing rules ordered by priority",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Master"
                 ],
-                "summary": "Get order history",
+                "summary": "Get pricing rules",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseOrderHistory"
+                            "$ref": "#/definitions/models.ResponsePricingRules"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a base price rule. Empty conditions match every schedule. When several rules match, the highest priority wins, then the rule with the most conditions, then the newest rule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Create a pricing rule",
+                "parameters": [
+                    {
+                        "description": "Pricing rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cinema not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/master/pricing-rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the conditions and price of a pricing rule. Existing orders keep the price they were sold at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Update a pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a pricing rule, schedules fall back to the next matching rule or the cinema price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Delete a pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "401": {
@@ -959,6 +1290,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -967,95 +1304,5495 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AdminDelete": {
+        },
+        "/master/promo-codes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all promo codes with their restrictions and active usage count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Get promo codes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePromoCodes"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a percentage or fixed discount code. Empty movie, cinema or payment method lists mean the code is valid for all of them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Create a promo code",
+                "parameters": [
+                    {
+                        "description": "Promo code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie, cinema or payment method not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Code already used",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/master/promo-codes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Get a promo code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promo code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace a promo code and its restrictions. Orders that already used the code keep their discount.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Update a promo code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promo code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promo code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Code already used",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Delete a promo code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promo code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/master/schedules/{id}/pricing": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Explain the base price of a schedule: every active rule is evaluated in priority order with the reasons it did not match, and the seat class prices that follow from the applied rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Preview schedule pricing",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePricingPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/master/seat-classes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all seat classes with their fixed price or modifier on top of the schedule base price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Get seat classes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatClasses"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a seat class. Fill price for a fixed price, or leave it empty to charge the schedule base price plus modifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Create a seat class",
+                "parameters": [
+                    {
+                        "description": "Seat class",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatClassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatClass"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Code already used",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/master/seat-classes/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the name, fixed price or modifier of a seat class. Existing orders keep the price they were sold at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Master"
+                ],
+                "summary": "Update a seat class price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Seat class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seat class",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatClassUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatClass"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve movies filtered by title, genres, and page number (supports Redis caching)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get filtered movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by movie title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by genres, comma-separated",
+                        "name": "genres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMovies"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/popular": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of popular movies (cached in Redis for 10 minutes)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get popular movies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMovies"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of upcoming movies (cached in Redis for 10 minutes)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get upcoming movies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMovies"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information for a single movie by ID (cached in Redis for 10 minutes)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get movie details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMovieDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new order with seats and associate it with the logged-in user. Every seat must be held by the user first. Points are redeemed after the promo discount and never exceed the remaining total. An order holds at most MAX_SEATS_PER_ORDER seats and an account at most MAX_SEATS_PER_USER_SCHEDULE seats per schedule. Wheelchair spaces need accessible set to true and companion seats must be ordered with the wheelchair space next to them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and body replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Order request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrders"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat already booked or not held by user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Promo code rejected, not enough points, seat limit exceeded, selection leaves a single empty seat, or Idempotency-Key reused with a different body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/conflicts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the seats that were sold to more than one order before seats became unique per schedule. The migration kept the seat on kept_order_id and removed it from order_id, so these orders need a refund or a new seat.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get double-sold seat conflicts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderConflicts"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/guest": {
+            "post": {
+                "description": "Checkout without an account. Seats must be held first through /schedule/seat/{id}/guest-hold with the same X-Guest-Token. Tickets can be retrieved later with POST /order/lookup. Points cannot be redeemed, and the promo per-user limit is counted per email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a guest order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest session token used to hold the seats",
+                        "name": "X-Guest-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and body replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Order request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrders"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing guest token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat already booked or not held by the guest session",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Promo code rejected, seat limit exceeded, selection leaves a single empty seat, or Idempotency-Key reused with a different body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/lookup": {
+            "post": {
+                "description": "Retrieve the tickets of an order with the email used at checkout and its booking code, no login required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Look up an order",
+                "parameters": [
+                    {
+                        "description": "Email and booking code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderLookupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderLookup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/lookup/pay": {
+            "post": {
+                "description": "Create a charge for an order identified by the email used at checkout and its booking code, used by guest checkout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Pay a looked up order",
+                "parameters": [
+                    {
+                        "description": "Email and booking code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderLookupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/quote": {
+            "post": {
+                "description": "Calculate the price breakdown of the selected seats without booking anything. A promo code is validated and applied, except for the per-user limit which is checked when the order is created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Quote an order",
+                "parameters": [
+                    {
+                        "description": "Quote request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Promo code rejected",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the detail of an order: movie, schedule, cinema, seats, price breakdown, latest payment and ticket. Users can only read their own orders, admins can read any order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/admin-cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin override to cancel any order regardless of the cutoff, including tickets received by transfer, for example when a screening is cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel any order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderCancellation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already checked in or invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel the user's own order up to ORDER_CANCEL_CUTOFF_HOURS before the showtime. Seats are released, loyalty points are reversed and paid orders are refunded. Tickets received by transfer cannot be cancelled because the refund goes to the original payer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderCancellation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cutoff passed, ticket received by transfer, ticket already checked in or invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/change-seats": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Swap the seats of the user's own pending, awaiting payment or paid order for other available seats on the same schedule, up to ORDER_CANCEL_CUTOFF_HOURS before the showtime. The body lists the full new set of seats, seats that stay can be repeated. The whole swap fails if any new seat is already sold or held by someone else. The class price difference changes the order total: unpaid orders pay the new total and paid orders are refunded a lower amount. When a paid order moves to dearer seats the order is left unchanged and the new seats are held while a payment for the extra amount is created; the seats are swapped once that payment is paid and released if it fails or expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Change the seats of an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and body replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New seats",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat already booked or held, cutoff passed, ticket received by transfer, ticket already checked in, a seat change already waiting for payment, or order status does not allow changes",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "New seats leave a single empty seat",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a charge at the payment provider mapped to the order's payment method and move the order to awaiting_payment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Pay an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/qrcode": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Render the signed ticket payload of an order as a PNG QR code, only the owner of the order can access it",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order ticket QR code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move an order to awaiting_payment, paid or expired, only allowed transitions are accepted. Cancellations and refunds go through POST /order/{id}/admin-cancel so seats, promos and the refund are handled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Offer the user's own paid, unused order to another registered user by email, up to TRANSFER_CUTOFF_HOURS before the showtime. The order moves only after the recipient accepts, an order can have one pending transfer at a time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Transfer a ticket to another user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipient email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or recipient not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order not transferable, cutoff passed or transfer already pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/sandbox/{reference}": {
+            "post": {
+                "description": "Change the status of a mock charge and deliver the signed webhook, only available when PAYMENT_SANDBOX=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Drive a mock payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Charge reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target charge status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PaymentSandboxRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/va/{number}/transfer": {
+            "post": {
+                "description": "Pay a virtual account as if the bank received a transfer, then deliver the signed webhook that marks the order paid. The amount must match the virtual account exactly. Only available when PAYMENT_SANDBOX=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Simulate a bank transfer to a virtual account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Virtual account number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VATransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid number or amount mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Virtual account already paid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/webhook": {
+            "post": {
+                "description": "Callback from the payment provider, the raw body must be signed with HMAC-SHA256 in the X-Signature header. A paid charge for an order that expired, was cancelled or is already paid is recorded and refunded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hex HMAC-SHA256 of the body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook event",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payments.WebhookEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/seat/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the auditorium seat map of a schedule with the status and accessibility attribute (wheelchair or companion) of each seat, plus the sold and currently held seat ids. accessible_released tells whether unsold wheelchair spaces and companion seats are already open to everyone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get seat map",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/seat/{id}/guest-hold": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reserve seats of a schedule for the logged-in user, or for the guest session in X-Guest-Token on guest-hold, for a limited time before checkout. Wheelchair spaces need accessible set to true and companion seats must be held together with the wheelchair space next to them, until they are released to general sale shortly before the showtime.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Hold seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest session token, required on guest-hold",
+                        "name": "X-Guest-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Seats to hold",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatHold"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat already sold or held",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Seat limit per order or per account exceeded, or selection leaves a single empty seat",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Release seats held by the logged-in user or guest session, all of them when no seat is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Release held seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest session token, required on guest-hold",
+                        "name": "X-Guest-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Seats to release",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatHoldUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatRelease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Extend the hold of seats held by the logged-in user or guest session, all of them when no seat is given. A hold cannot last longer than SEAT_HOLD_MINUTES plus SEAT_HOLD_MAX_EXTENSION_MINUTES from when the seat was first held.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Extend held seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest session token, required on guest-hold",
+                        "name": "X-Guest-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Seats to extend",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatHoldUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatHold"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Hold not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Hold extension limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/seat/{id}/hold": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reserve seats of a schedule for the logged-in user, or for the guest session in X-Guest-Token on guest-hold, for a limited time before checkout. Wheelchair spaces need accessible set to true and companion seats must be held together with the wheelchair space next to them, until they are released to general sale shortly before the showtime.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Hold seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest session token, required on guest-hold",
+                        "name": "X-Guest-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Seats to hold",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatHold"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat already sold or held",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Seat limit per order or per account exceeded, or selection leaves a single empty seat",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Release seats held by the logged-in user or guest session, all of them when no seat is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Release held seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest session token, required on guest-hold",
+                        "name": "X-Guest-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Seats to release",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatHoldUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatRelease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Extend the hold of seats held by the logged-in user or guest session, all of them when no seat is given. A hold cannot last longer than SEAT_HOLD_MINUTES plus SEAT_HOLD_MAX_EXTENSION_MINUTES from when the seat was first held.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Extend held seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest session token, required on guest-hold",
+                        "name": "X-Guest-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Seats to extend",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatHoldUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSeatHold"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Hold not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Hold extension limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/seat/{id}/waitlist": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queue the logged-in user for seat_count seats of a schedule that does not have enough seats left. When seats free up through expiry or cancellation, the first user in line gets the seats held for WAITLIST_OFFER_MINUTES and a notification, then books them through the normal order endpoint. An offer that is not booked in time passes to the next user in line.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Join the waitlist of a schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Number of seats",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WaitlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseWaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seats still available, already on the waitlist or schedule started",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Seat limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove the logged-in user from the waitlist of a schedule. Seats currently offered to the user are released and offered to the next user in line.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Leave the waitlist of a schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseWaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not on the waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all schedules for a specific movie by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get movie schedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the transfers sent and received by the user that are still waiting for the recipient",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get pending ticket transfers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderTransfers"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Accept a transfer sent to the user. The order moves to the user's account with the user's contact details, a new booking code and QR code are issued and the old ones stop working. The order's seats count towards the user's seat limit for the schedule.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Accept a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already answered, order no longer transferable or cutoff passed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Seat limit per account exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Withdraw a transfer the user sent before the recipient answers it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already answered",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Decline a transfer sent to the user, the order stays with the sender",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Decline a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already answered",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil data profil user yang sedang login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseUserProfile"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengupdate profil user termasuk upload profile image",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Profile Image",
+                        "name": "profileimg",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First Name",
+                        "name": "firstname",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last Name",
+                        "name": "lastname",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseUpdateProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil riwayat order user yang sedang login per halaman, bisa difilter upcoming / past berdasarkan jam tayang, status dan movie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get order history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Orders per page (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "upcoming or past, based on the showtime",
+                        "name": "when",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "newest (default), oldest, showtime_asc or showtime_desc",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseOrderHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil notifikasi terbaru user, misalnya tawaran kursi dari waitlist",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of notifications (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseNotifications"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/notifications/read": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menandai semua notifikasi user sudah dibaca",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mark notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/orders/claim": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move orders placed through guest checkout into the logged-in account. Each order needs its booking code and must have been placed with the account's email. Loyalty points are not earned retroactively.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Claim guest orders",
+                "parameters": [
+                    {
+                        "description": "Booking codes of the guest orders",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ClaimOrdersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseClaimOrders"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/points": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil saldo poin user yang sedang login beserta ledger perubahan poin terbaru",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get loyalty points",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of ledger entries (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseUserPoints"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/va": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil nomor virtual account yang masih menunggu transfer untuk order user, beserta nominal dan batas waktunya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get active virtual accounts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseUserVAs"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/waitlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil antrean waitlist user yang masih menunggu beserta posisinya, dan kursi yang sedang ditawarkan beserta batas waktu checkout",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get waitlist entries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseWaitlistEntries"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AdminDelete": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Movie successfully deleted"
+                }
+            }
+        },
+        "models.AdminMovie": {
+            "type": "object",
+            "properties": {
+                "backdrop": {
+                    "type": "string",
+                    "example": "https://image.example.com/backdrops/avengers_endgame.jpg"
+                },
+                "casts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"Robert Downey Jr.\"",
+                        " \"Chris Evans\"",
+                        " \"Mark Ruffalo\"",
+                        " \"Chris Hemsworth\"]"
+                    ]
+                },
+                "director": {
+                    "type": "string",
+                    "example": "Anthony Russo, Joe Russo"
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 181
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"Action\"",
+                        " \"Adventure\"",
+                        " \"Sci-Fi\"]"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "poster": {
+                    "type": "string",
+                    "example": "https://image.example.com/posters/avengers_endgame.jpg"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 8.4
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2019-04-26T00:00:00Z"
+                },
+                "synopsis": {
+                    "type": "string",
+                    "example": "Setelah pertempuran melawan Thanos, para Avengers bersatu untuk mengembalikan keseimbangan di alam semesta."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame"
+                }
+            }
+        },
+        "models.AdminMovieDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.AdminDelete"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Movie fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.AdminMovieResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.AdminMovie"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Movie fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.AdminMovieUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.AdminUpdate"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Movie fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.AdminUpdate": {
+            "type": "object",
+            "properties": {
+                "backdrop": {
+                    "type": "string",
+                    "example": "https://image.example.com/backdrops/avengers_endgame_cut.jpg"
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 183
+                },
+                "id_director": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "poster": {
+                    "type": "string",
+                    "example": "https://image.example.com/posters/avengers_endgame_cut.jpg"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 8.5
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2019-05-01T00:00:00Z"
+                },
+                "synopsis": {
+                    "type": "string",
+                    "example": "Versi director's cut dari Avengers: Endgame."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame - Director's Cut"
+                }
+            }
+        },
+        "models.Auditorium": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 112
+                },
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "layout": {
+                    "$ref": "#/definitions/models.SeatLayout"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Studio 1"
+                }
+            }
+        },
+        "models.AuditoriumRequest": {
+            "type": "object",
+            "required": [
+                "layout",
+                "name"
+            ],
+            "properties": {
+                "layout": {
+                    "$ref": "#/definitions/models.SeatLayout"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Studio 1"
+                }
+            }
+        },
+        "models.CheckinRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                }
+            }
+        },
+        "models.CheckinResponse": {
+            "type": "object",
+            "properties": {
+                "booking_code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                },
+                "checkin_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:05:00Z"
+                },
+                "cinema": {
+                    "type": "string",
+                    "example": "Cineworld"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "seat": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
+                },
+                "time": {
+                    "type": "string",
+                    "example": "19:30:00"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Inception"
+                }
+            }
+        },
+        "models.CinemaSeatRules": {
+            "type": "object",
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "no_orphan_seat": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.CinemaSeatRulesRequest": {
+            "type": "object",
+            "required": [
+                "no_orphan_seat"
+            ],
+            "properties": {
+                "no_orphan_seat": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ClaimOrdersRequest": {
+            "type": "object",
+            "required": [
+                "booking_codes"
+            ],
+            "properties": {
+                "booking_codes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "K7QM2XR9TB"
+                    ]
+                }
+            }
+        },
+        "models.ClaimOrdersResult": {
+            "type": "object",
+            "properties": {
+                "not_found": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ZZZZZZZZZZ"
+                    ]
+                },
+                "order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        501
+                    ]
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string",
+                    "example": "Error Message"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "HTTP Status Error"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GenreResponse": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                }
+            }
+        },
+        "models.Holiday": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-12-25"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hari Natal"
+                }
+            }
+        },
+        "models.HolidayRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "name"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-12-25"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hari Natal"
+                }
+            }
+        },
+        "models.LoginDocs": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "token"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Login successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Movie": {
+            "type": "object",
+            "properties": {
+                "backdrop": {
+                    "type": "string",
+                    "example": "inception_backdrop.jpg"
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 148
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Action",
+                        "Sci-Fi",
+                        "Thriller"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "poster": {
+                    "type": "string",
+                    "example": "inception.jpg"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 8.8
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2010-07-16"
+                },
+                "synopsis": {
+                    "type": "string",
+                    "example": "A thief who enters the dreams of others to steal secrets must pull off the ultimate heist."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Inception"
+                }
+            }
+        },
+        "models.MovieDetail": {
+            "type": "object",
+            "properties": {
+                "backdrop": {
+                    "type": "string",
+                    "example": "inception_backdrop.jpg"
+                },
+                "casts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Leonardo DiCaprio",
+                        " Joseph Gordon-Levitt",
+                        " Ellen Page"
+                    ]
+                },
+                "director": {
+                    "type": "string",
+                    "example": "Christopher Nolan"
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 148
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Action",
+                        "Sci-Fi",
+                        "Thriller"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "poster": {
+                    "type": "string",
+                    "example": "inception.jpg"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 8.8
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2010-07-16T00:00:00Z"
+                },
+                "synopsis": {
+                    "type": "string",
+                    "example": "A thief who enters the dreams of others to steal secrets must pull off the ultimate heist."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Inception"
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T18:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Seats D4, D5 for Avengers: Endgame are held for you until 18:10, complete your order before then"
+                },
+                "read_at": {
+                    "type": "string",
+                    "example": "2025-09-20T18:02:00Z"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "type": {
+                    "type": "string",
+                    "example": "waitlist_offer"
+                }
+            }
+        },
+        "models.OrderCancelRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Screening cancelled"
+                }
+            }
+        },
+        "models.OrderCancellation": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "refund": {
+                    "$ref": "#/definitions/models.Refund"
+                }
+            }
+        },
+        "models.OrderClassItem": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string",
+                    "example": "premium"
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "Premium"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "G1",
+                        "G2"
+                    ]
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "unit_price": {
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "models.OrderConflict": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "rangga@example.com"
+                },
+                "flagged_at": {
+                    "type": "string",
+                    "example": "2025-09-20T10:00:00Z"
+                },
+                "kept_order_id": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 87
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "paid"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "seat": {
+                    "type": "string",
+                    "example": "A5"
+                }
+            }
+        },
+        "models.OrderDetail": {
+            "type": "object",
+            "properties": {
+                "accessible": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "auditorium": {
+                    "type": "string",
+                    "example": "Studio 1"
+                },
+                "awaiting_payment_at": {
+                    "type": "string"
+                },
+                "booking_code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "cinema_logo": {
+                    "type": "string",
+                    "example": "XXI.jpg"
+                },
+                "cinema_name": {
+                    "type": "string",
+                    "example": "XXI Plaza Indonesia"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "email": {
+                    "type": "string",
+                    "example": "rangga@example.com"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "ispaid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderClassItem"
+                    }
+                },
+                "location_name": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "movie_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "movie_poster": {
+                    "type": "string",
+                    "example": "avengers.jpg"
+                },
+                "movie_title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Rangga Saputra"
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:35:00Z"
+                },
+                "payment": {
+                    "$ref": "#/definitions/models.Payment"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "BCA"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+628123456789"
+                },
+                "points_used": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "promo": {
+                    "$ref": "#/definitions/models.OrderPromo"
+                },
+                "qrcode": {
+                    "type": "string",
+                    "example": "TKT.101.12.K7QM2XR9TB.3q2-7wX9..."
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 8.5
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
+                },
+                "show_date": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "show_time": {
+                    "type": "string",
+                    "example": "19:30"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "paid"
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "total_price": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.OrderHistory": {
+            "type": "object",
+            "properties": {
+                "booking_code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                },
+                "cinema_logo": {
+                    "type": "string",
+                    "example": "XXI.jpg"
+                },
+                "cinema_name": {
+                    "type": "string",
+                    "example": "XXI Plaza Indonesia"
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "ispaid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderClassItem"
+                    }
+                },
+                "location_name": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "movie_backdrop": {
+                    "type": "string",
+                    "example": "https://example.com/backdrops/avengers-bg.jpg"
+                },
+                "movie_poster": {
+                    "type": "string",
+                    "example": "https://example.com/posters/avengers.jpg"
+                },
+                "movie_title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame"
+                },
+                "order_email": {
+                    "type": "string",
+                    "example": "rangga@example.com"
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 501
+                },
+                "order_name": {
+                    "type": "string",
+                    "example": "Rangga Saputra"
+                },
+                "order_phone": {
+                    "type": "string",
+                    "example": "+628123456789"
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:35:00Z"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "Credit Card"
+                },
+                "qrcode": {
+                    "type": "string",
+                    "example": "TKT.501.12.K7QM2XR9TB.3q2-7wX9..."
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 8.5
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"A1\"",
+                        "\"A2\"",
+                        "\"A3\"]"
+                    ]
+                },
+                "show_date": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "show_time": {
+                    "type": "string",
+                    "example": "19:30"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "paid"
+                },
+                "total_price": {
+                    "type": "integer",
+                    "example": 150000
+                }
+            }
+        },
+        "models.OrderLookupRequest": {
+            "type": "object",
+            "required": [
+                "booking_code",
+                "email"
+            ],
+            "properties": {
+                "booking_code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "rangga@example.com"
+                }
+            }
+        },
+        "models.OrderPromo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "NONTONHEMAT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Diskon 20% maksimal 30"
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "models.OrderQuote": {
+            "type": "object",
+            "properties": {
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderClassItem"
+                    }
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderQuoteItem"
+                    }
+                },
+                "points_discount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "points_used": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "promo": {
+                    "$ref": "#/definitions/models.OrderPromo"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "unit_price": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "models.OrderQuoteItem": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string",
+                    "example": "regular"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "seat": {
+                    "type": "string",
+                    "example": "A1"
+                }
+            }
+        },
+        "models.OrderQuoteRequest": {
+            "type": "object",
+            "required": [
+                "id_schedule",
+                "seat"
+            ],
+            "properties": {
+                "id_paymentmethod": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "id_schedule": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "NONTONHEMAT"
+                },
+                "seat": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.OrderRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "id_paymentmethod",
+                "id_schedule",
+                "name",
+                "phone",
+                "seat"
+            ],
+            "properties": {
+                "accessible": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id_paymentmethod": {
+                    "type": "integer"
+                },
+                "id_schedule": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "NONTONHEMAT"
+                },
+                "seat": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "accessible": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "booking_code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                },
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderClassItem"
+                    }
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "email": {
+                    "type": "string",
+                    "example": "rangga@example.com"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderQuoteItem"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Rangga Saputra"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+628123456789"
+                },
+                "points_used": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "promo": {
+                    "$ref": "#/definitions/models.OrderPromo"
+                },
+                "qrcode": {
+                    "type": "string",
+                    "example": "TKT.101.12.K7QM2XR9TB.3q2-7wX9..."
+                },
+                "seat": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2",
+                        "A3"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "total_price": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "models.OrderStatus": {
+            "type": "object",
+            "properties": {
+                "awaiting_payment_at": {
+                    "type": "string"
+                },
+                "booking_code": {
+                    "type": "string",
+                    "example": "K7QM2XR9TB"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:35:00Z"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "seat": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "paid"
+                },
+                "total_price": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.OrderStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "awaiting_payment",
+                        "paid",
+                        "expired"
+                    ],
+                    "example": "paid"
+                }
+            }
+        },
+        "models.OrderTransfer": {
+            "type": "object",
+            "properties": {
+                "booking_code": {
+                    "type": "string",
+                    "example": "P4ZN8WQ2HC"
+                },
+                "cinema_name": {
+                    "type": "string",
+                    "example": "XXI Plaza Indonesia"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T10:00:00Z"
+                },
+                "from_email": {
+                    "type": "string",
+                    "example": "rangga@example.com"
+                },
+                "from_user_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "movie_title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame"
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "responded_at": {
+                    "type": "string",
+                    "example": "2025-09-20T10:05:00Z"
+                },
+                "seat": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
+                },
+                "show_date": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "show_time": {
+                    "type": "string",
+                    "example": "19:30"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "to_email": {
+                    "type": "string",
+                    "example": "budi@example.com"
+                },
+                "to_user_id": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "models.OrderTransferRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "budi@example.com"
+                }
+            }
+        },
+        "models.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "payment_url": {
+                    "type": "string",
+                    "example": "/payment/sandbox/MOCK-8f2c1d9a7b6e5f40"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "mock"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "MOCK-8f2c1d9a7b6e5f40"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "models.PaymentSandboxRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "paid",
+                        "failed",
+                        "expired"
+                    ]
+                }
+            }
+        },
+        "models.PointLedgerEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:35:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 501
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "earn"
+                }
+            }
+        },
+        "models.PricingClassPrice": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string",
+                    "example": "premium"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Premium"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 70
+                }
+            }
+        },
+        "models.PricingPreview": {
+            "type": "object",
+            "properties": {
+                "applied_rule": {
+                    "$ref": "#/definitions/models.PricingRule"
+                },
+                "cinema": {
+                    "type": "string",
+                    "example": "Cineworld"
+                },
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "cinema_price": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PricingClassPrice"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-12-26"
+                },
+                "day_of_week": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "rule #1 Weekend applied: cinema price 50 + modifier 10"
+                },
+                "holiday": {
+                    "type": "string",
+                    "example": "Hari Natal"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PricingRuleMatch"
+                    }
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "time": {
+                    "type": "string",
+                    "example": "19:00:00"
+                }
+            }
+        },
+        "models.PricingRule": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        6,
+                        7
+                    ]
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2027-01-05"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "23:45:00"
+                },
+                "holiday": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "modifier": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekend"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 75
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-12-20"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "17:00:00"
+                }
+            }
+        },
+        "models.PricingRuleMatch": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "matched": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rule": {
+                    "$ref": "#/definitions/models.PricingRule"
+                },
+                "specificity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.PricingRuleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cinema_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "days": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        6,
+                        7
+                    ]
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2027-01-05"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "23:45:00"
+                },
+                "holiday": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "modifier": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekend"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 75
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-12-20"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "17:00:00"
+                }
+            }
+        },
+        "models.PromoCode": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cinema_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "example": "NONTONHEMAT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Diskon 20% maksimal 30"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percent"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_discount": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "min_spend": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "payment_method_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2026-10-01T00:00:00Z"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.PromoCodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "discount_type",
+                "discount_value",
+                "end_at",
+                "start_at"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cinema_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "NONTONHEMAT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Diskon 20% maksimal 30"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 20
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59Z"
+                },
+                "max_discount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 30
+                },
+                "min_spend": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "payment_method_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2026-10-01T00:00:00Z"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 500
+                }
+            }
+        },
+        "models.Refund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 101
+                },
+                "payment_id": {
+                    "type": "integer",
+                    "example": 33
+                },
+                "payment_reference": {
+                    "type": "string",
+                    "example": "MOCK-1a2b3c4d5e6f7a8b"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "mock"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Screening cancelled"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "MOCKRF-1a2b3c4d5e6f7a8b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "refunded"
+                }
+            }
+        },
+        "models.RegisterDocs": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Register successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "models.ResponseAuditorium": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Auditorium"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Auditorium"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseAuditoriums": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Auditorium"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Auditoriums"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseCheckin": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CheckinResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Check In"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseCinemaSeatRules": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CinemaSeatRules"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Update Seat Rules"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseClaimOrders": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ClaimOrdersResult"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Claim Orders"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseHoliday": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Holiday"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Holiday"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseHolidays": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Holiday"
+                    }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Movie successfully deleted"
+                    "example": "Success Load Holidays"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.AdminMovie": {
+        "models.ResponseMessage": {
             "type": "object",
             "properties": {
-                "backdrop": {
+                "data": {},
+                "message": {
                     "type": "string",
-                    "example": "https://image.example.com/backdrops/avengers_endgame.jpg"
+                    "example": "Success Delete"
                 },
-                "casts": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseMovieDetail": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.MovieDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Movies"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseMovies": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"Robert Downey Jr.\"",
-                        " \"Chris Evans\"",
-                        " \"Mark Ruffalo\"",
-                        " \"Chris Hemsworth\"]"
-                    ]
+                        "$ref": "#/definitions/models.Movie"
+                    }
                 },
-                "director": {
+                "message": {
                     "type": "string",
-                    "example": "Anthony Russo, Joe Russo"
-                },
-                "duration": {
-                    "type": "integer",
-                    "example": 181
+                    "example": "Success Load Movies"
                 },
-                "genres": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseNotifications": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"Action\"",
-                        " \"Adventure\"",
-                        " \"Sci-Fi\"]"
-                    ]
+                        "$ref": "#/definitions/models.Notification"
+                    }
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Notifications"
                 },
-                "poster": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseOrderCancellation": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.OrderCancellation"
+                },
+                "message": {
                     "type": "string",
-                    "example": "https://image.example.com/posters/avengers_endgame.jpg"
+                    "example": "Success Cancel Order"
                 },
-                "rating": {
-                    "type": "number",
-                    "example": 8.4
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseOrderConflicts": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderConflict"
+                    }
                 },
-                "release_date": {
+                "message": {
                     "type": "string",
-                    "example": "2019-04-26T00:00:00Z"
+                    "example": "Success Load Order Conflicts"
                 },
-                "synopsis": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseOrderDetail": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.OrderDetail"
+                },
+                "message": {
                     "type": "string",
-                    "example": "Setelah pertempuran melawan Thanos, para Avengers bersatu untuk mengembalikan keseimbangan di alam semesta."
+                    "example": "Success Load Order"
                 },
-                "title": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseOrderHistory": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.OrderHistory"
+                },
+                "message": {
                     "type": "string",
-                    "example": "Avengers: Endgame"
+                    "example": "Success Load Movies"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.AdminMovieDeleteResponse": {
+        "models.ResponseOrderLookup": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.AdminDelete"
+                    "$ref": "#/definitions/models.OrderHistory"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Movie fetched successfully"
+                    "example": "Success Load Order"
                 },
                 "success": {
                     "type": "boolean",
@@ -1063,15 +6800,15 @@ const docTemplate = `{
                 }
             }
         },
-        "models.AdminMovieResponse": {
+        "models.ResponseOrderQuote": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.AdminMovie"
+                    "$ref": "#/definitions/models.OrderQuote"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Movie fetched successfully"
+                    "example": "Success Quote Order"
                 },
                 "success": {
                     "type": "boolean",
@@ -1079,15 +6816,15 @@ const docTemplate = `{
                 }
             }
         },
-        "models.AdminMovieUpdateResponse": {
+        "models.ResponseOrderStatus": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.AdminUpdate"
+                    "$ref": "#/definitions/models.OrderStatus"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Movie fetched successfully"
+                    "example": "Success Load Order"
                 },
                 "success": {
                     "type": "boolean",
@@ -1095,96 +6832,168 @@ const docTemplate = `{
                 }
             }
         },
-        "models.AdminUpdate": {
+        "models.ResponseOrderTransfer": {
             "type": "object",
             "properties": {
-                "backdrop": {
+                "data": {
+                    "$ref": "#/definitions/models.OrderTransfer"
+                },
+                "message": {
                     "type": "string",
-                    "example": "https://image.example.com/backdrops/avengers_endgame_cut.jpg"
+                    "example": "Success Transfer Order"
                 },
-                "duration": {
-                    "type": "integer",
-                    "example": 183
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseOrderTransfers": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderTransfer"
+                    }
                 },
-                "id_director": {
-                    "type": "integer",
-                    "example": 12
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Transfers"
                 },
-                "poster": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseOrders": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.OrderResponse"
+                },
+                "message": {
                     "type": "string",
-                    "example": "https://image.example.com/posters/avengers_endgame_cut.jpg"
+                    "example": "Success Load Movies"
                 },
-                "rating": {
-                    "type": "number",
-                    "example": 8.5
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponsePayment": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Payment"
                 },
-                "release_date": {
+                "message": {
                     "type": "string",
-                    "example": "2019-05-01T00:00:00Z"
+                    "example": "Success Create Payment"
                 },
-                "synopsis": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponsePricingPreview": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.PricingPreview"
+                },
+                "message": {
                     "type": "string",
-                    "example": "Versi director's cut dari Avengers: Endgame."
+                    "example": "Success Preview Pricing"
                 },
-                "title": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponsePricingRule": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.PricingRule"
+                },
+                "message": {
                     "type": "string",
-                    "example": "Avengers: Endgame - Director's Cut"
+                    "example": "Success Load Pricing Rule"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.ResponsePricingRules": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Error Message"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PricingRule"
+                    }
                 },
-                "status": {
+                "message": {
                     "type": "string",
-                    "example": "HTTP Status Error"
-                },
-                "status_code": {
-                    "type": "integer",
-                    "example": 400
+                    "example": "Success Load Pricing Rules"
                 },
                 "success": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 }
             }
         },
-        "models.Genre": {
+        "models.ResponsePromoCode": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/models.PromoCode"
                 },
-                "name": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Promo Code"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.GenreResponse": {
+        "models.ResponsePromoCodes": {
             "type": "object",
             "properties": {
-                "genres": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Genre"
+                        "$ref": "#/definitions/models.PromoCode"
                     }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success Load Promo Codes"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.LoginDocs": {
+        "models.ResponseSchedule": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "string",
-                    "example": "token"
+                    "$ref": "#/definitions/models.ScheduleResponse"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Login successful"
+                    "example": "Success Load Movies"
                 },
                 "success": {
                     "type": "boolean",
@@ -1192,282 +7001,292 @@ const docTemplate = `{
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.ResponseSeatChange": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/models.SeatChange"
                 },
-                "password": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Success Change Seats"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.Movie": {
+        "models.ResponseSeatClass": {
             "type": "object",
             "properties": {
-                "backdrop": {
-                    "type": "string",
-                    "example": "inception_backdrop.jpg"
-                },
-                "duration": {
-                    "type": "integer",
-                    "example": 148
-                },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Action",
-                        "Sci-Fi",
-                        "Thriller"
-                    ]
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "poster": {
-                    "type": "string",
-                    "example": "inception.jpg"
-                },
-                "rating": {
-                    "type": "number",
-                    "example": 8.8
-                },
-                "release_date": {
-                    "type": "string",
-                    "example": "2010-07-16"
+                "data": {
+                    "$ref": "#/definitions/models.SeatClass"
                 },
-                "synopsis": {
+                "message": {
                     "type": "string",
-                    "example": "A thief who enters the dreams of others to steal secrets must pull off the ultimate heist."
+                    "example": "Success Load Seat Class"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Inception"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.MovieDetail": {
+        "models.ResponseSeatClasses": {
             "type": "object",
             "properties": {
-                "backdrop": {
-                    "type": "string",
-                    "example": "inception_backdrop.jpg"
-                },
-                "casts": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Leonardo DiCaprio",
-                        " Joseph Gordon-Levitt",
-                        " Ellen Page"
-                    ]
-                },
-                "director": {
-                    "type": "string",
-                    "example": "Christopher Nolan"
-                },
-                "duration": {
-                    "type": "integer",
-                    "example": 148
-                },
-                "genres": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Action",
-                        "Sci-Fi",
-                        "Thriller"
-                    ]
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                        "$ref": "#/definitions/models.SeatClass"
+                    }
                 },
-                "poster": {
+                "message": {
                     "type": "string",
-                    "example": "inception.jpg"
-                },
-                "rating": {
-                    "type": "number",
-                    "example": 8.8
+                    "example": "Success Load Seat Classes"
                 },
-                "release_date": {
-                    "type": "string",
-                    "example": "2010-07-16T00:00:00Z"
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseSeatHold": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SeatHoldResponse"
                 },
-                "synopsis": {
+                "message": {
                     "type": "string",
-                    "example": "A thief who enters the dreams of others to steal secrets must pull off the ultimate heist."
+                    "example": "Success Hold Seats"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Inception"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.OrderHistory": {
+        "models.ResponseSeatRelease": {
             "type": "object",
             "properties": {
-                "cinema_name": {
-                    "type": "string",
-                    "example": "XXI Plaza Indonesia"
+                "data": {
+                    "$ref": "#/definitions/models.SeatReleaseResponse"
                 },
-                "duration": {
-                    "type": "integer",
-                    "example": 180
+                "message": {
+                    "type": "string",
+                    "example": "Success Release Seats"
                 },
-                "ispaid": {
+                "success": {
                     "type": "boolean",
                     "example": true
+                }
+            }
+        },
+        "models.ResponseSeats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SeatResponse"
                 },
-                "location_name": {
+                "message": {
                     "type": "string",
-                    "example": "Jakarta"
+                    "example": "Success Load Movies"
                 },
-                "movie_backdrop": {
-                    "type": "string",
-                    "example": "https://example.com/backdrops/avengers-bg.jpg"
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseUpdateProfile": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.UpdateProfile"
                 },
-                "movie_poster": {
+                "message": {
                     "type": "string",
-                    "example": "https://example.com/posters/avengers.jpg"
+                    "example": "Success Load Movies"
                 },
-                "movie_title": {
-                    "type": "string",
-                    "example": "Avengers: Endgame"
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseUserPoints": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.UserPoints"
                 },
-                "order_email": {
+                "message": {
                     "type": "string",
-                    "example": "rangga@example.com"
-                },
-                "order_id": {
-                    "type": "integer",
-                    "example": 501
+                    "example": "Success Load Points"
                 },
-                "order_name": {
-                    "type": "string",
-                    "example": "Rangga Saputra"
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseUserProfile": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.UserProfile"
                 },
-                "order_phone": {
+                "message": {
                     "type": "string",
-                    "example": "+628123456789"
+                    "example": "Success Load Movies"
                 },
-                "payment_method": {
-                    "type": "string",
-                    "example": "Credit Card"
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseUserVAs": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserVA"
+                    }
                 },
-                "qrcode": {
+                "message": {
                     "type": "string",
-                    "example": "https://example.com/qrcode/501.png"
-                },
-                "rating": {
-                    "type": "number",
-                    "example": 8.5
+                    "example": "Success Load Virtual Accounts"
                 },
-                "seats": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseWaitlistEntries": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"A1\"",
-                        "\"A2\"",
-                        "\"A3\"]"
-                    ]
+                        "$ref": "#/definitions/models.WaitlistEntry"
+                    }
                 },
-                "show_date": {
+                "message": {
                     "type": "string",
-                    "example": "2025-09-20T19:30:00Z"
+                    "example": "Success Load Waitlist"
                 },
-                "show_time": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.ResponseWaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.WaitlistEntry"
+                },
+                "message": {
                     "type": "string",
-                    "example": "19:30"
+                    "example": "Success Join Waitlist"
                 },
-                "total_price": {
-                    "type": "integer",
-                    "example": 150000
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.OrderRequest": {
+        "models.Schedule": {
             "type": "object",
-            "required": [
-                "email",
-                "id_paymentmethod",
-                "id_schedule",
-                "name",
-                "phone",
-                "qrcode",
-                "total_price"
-            ],
             "properties": {
-                "email": {
+                "cinema": {
                     "type": "string"
                 },
-                "id_paymentmethod": {
-                    "type": "integer"
+                "cinema_img": {
+                    "type": "string"
                 },
-                "id_schedule": {
-                    "type": "integer"
+                "date": {
+                    "type": "string"
                 },
-                "is_paid": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
-                "name": {
+                "location": {
                     "type": "string"
                 },
-                "phone": {
+                "price": {
+                    "type": "integer"
+                },
+                "pricing_rule": {
                     "type": "string"
                 },
-                "qrcode": {
+                "show_time": {
                     "type": "string"
+                }
+            }
+        },
+        "models.ScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "movie_id": {
+                    "type": "integer",
+                    "example": 101
                 },
-                "seat": {
+                "schedule": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.Schedule"
                     }
-                },
-                "total_price": {
-                    "type": "number"
                 }
             }
         },
-        "models.OrderResponse": {
+        "models.SeatChange": {
             "type": "object",
             "properties": {
-                "email": {
+                "change_status": {
                     "type": "string",
-                    "example": "rangga@example.com"
+                    "example": "applied"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "from_seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1"
+                    ]
                 },
                 "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "order_id": {
                     "type": "integer",
                     "example": 101
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Rangga Saputra"
+                "payment": {
+                    "$ref": "#/definitions/models.Payment"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "+628123456789"
+                "price_difference": {
+                    "type": "integer",
+                    "example": 20
                 },
-                "qrcode": {
-                    "type": "string",
-                    "example": "https://example.com/qrcode/101.png"
+                "refund": {
+                    "$ref": "#/definitions/models.Refund"
                 },
                 "seat": {
                     "type": "array",
@@ -1475,221 +7294,385 @@ const docTemplate = `{
                         "type": "string"
                     },
                     "example": [
-                        "A1",
                         "A2",
-                        "A3"
+                        "C5"
                     ]
-                }
-            }
-        },
-        "models.RegisterDocs": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "string",
-                    "example": ""
                 },
-                "message": {
+                "status": {
                     "type": "string",
-                    "example": "Register successful"
+                    "example": "paid"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "to_seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "C5"
+                    ]
+                },
+                "total_price": {
+                    "type": "integer",
+                    "example": 120
                 }
             }
         },
-        "models.RegisterRequest": {
+        "models.SeatChangeRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "seat"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "seat": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "C5",
+                        "C6"
+                    ]
                 }
             }
         },
-        "models.ResponseMovieDetail": {
+        "models.SeatClass": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.MovieDetail"
+                "code": {
+                    "type": "string",
+                    "example": "couple"
                 },
-                "message": {
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "modifier": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "name": {
                     "type": "string",
-                    "example": "Success Load Movies"
+                    "example": "Couple Sofa"
                 },
-                "success": {
+                "pairs_only": {
                     "type": "boolean",
                     "example": true
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 80
                 }
             }
         },
-        "models.ResponseMovies": {
+        "models.SeatClassArea": {
             "type": "object",
+            "required": [
+                "class"
+            ],
             "properties": {
-                "data": {
+                "class": {
+                    "type": "string",
+                    "example": "premium"
+                },
+                "rows": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Movie"
-                    }
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Success Load Movies"
+                        "type": "string"
+                    },
+                    "example": [
+                        "G",
+                        "H"
+                    ]
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "F7",
+                        "F8"
+                    ]
                 }
             }
         },
-        "models.ResponseOrderHistory": {
+        "models.SeatClassRequest": {
             "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.OrderHistory"
+                "code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "couple"
                 },
-                "message": {
+                "modifier": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "name": {
                     "type": "string",
-                    "example": "Success Load Movies"
+                    "example": "Couple Sofa"
                 },
-                "success": {
+                "pairs_only": {
                     "type": "boolean",
                     "example": true
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 80
                 }
             }
         },
-        "models.ResponseOrders": {
+        "models.SeatClassUpdateRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.OrderResponse"
+                "modifier": {
+                    "type": "integer",
+                    "example": 20
                 },
-                "message": {
+                "name": {
                     "type": "string",
-                    "example": "Success Load Movies"
+                    "example": "Couple Sofa"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 80
                 }
             }
         },
-        "models.ResponseSchedule": {
+        "models.SeatHoldRequest": {
             "type": "object",
+            "required": [
+                "seat"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.ScheduleResponse"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Success Load Movies"
-                },
-                "success": {
+                "accessible": {
                     "type": "boolean",
-                    "example": true
+                    "example": false
+                },
+                "seat": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "models.ResponseSeats": {
+        "models.SeatHoldResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.SeatResponse"
-                },
-                "message": {
+                "expires_at": {
                     "type": "string",
-                    "example": "Success Load Movies"
+                    "example": "2025-09-20T19:40:00Z"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "seat": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
                 }
             }
         },
-        "models.ResponseUpdateProfile": {
+        "models.SeatHoldUpdateRequest": {
             "type": "object",
+            "required": [
+                "seat"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.UpdateProfile"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Success Load Movies"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "seat": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "models.ResponseUserProfile": {
+        "models.SeatLayout": {
             "type": "object",
+            "required": [
+                "columns",
+                "companion",
+                "disabled",
+                "gaps",
+                "rows",
+                "wheelchair"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.UserProfile"
+                "aisles": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        7
+                    ]
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Success Load Movies"
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SeatClassArea"
+                    }
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "columns": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1,
+                    "example": 14
+                },
+                "companion": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "H2"
+                    ]
+                },
+                "disabled": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "H14"
+                    ]
+                },
+                "gaps": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1"
+                    ]
+                },
+                "rows": {
+                    "type": "integer",
+                    "maximum": 26,
+                    "minimum": 1,
+                    "example": 8
+                },
+                "wheelchair": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "H1"
+                    ]
                 }
             }
         },
-        "models.Schedule": {
+        "models.SeatMapSeat": {
             "type": "object",
             "properties": {
-                "cinema": {
-                    "type": "string"
+                "access": {
+                    "type": "string",
+                    "example": "wheelchair"
                 },
-                "cinema_img": {
-                    "type": "string"
+                "class": {
+                    "type": "string",
+                    "example": "regular"
                 },
-                "date": {
-                    "type": "string"
+                "column": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "id": {
-                    "type": "integer"
+                    "type": "string",
+                    "example": "A1"
                 },
-                "location": {
-                    "type": "string"
+                "pair": {
+                    "type": "string",
+                    "example": "H2"
                 },
                 "price": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 50
                 },
-                "show_time": {
-                    "type": "string"
+                "row": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "available"
                 }
             }
         },
-        "models.ScheduleResponse": {
+        "models.SeatReleaseResponse": {
             "type": "object",
             "properties": {
-                "movie_id": {
+                "schedule_id": {
                     "type": "integer",
-                    "example": 101
+                    "example": 12
                 },
-                "schedule": {
+                "seat": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Schedule"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
                 }
             }
         },
         "models.SeatResponse": {
             "type": "object",
             "properties": {
+                "accessible_released": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "auditorium": {
+                    "type": "string",
+                    "example": "Studio 1"
+                },
+                "auditorium_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "held_seat_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "B4",
+                        "B5"
+                    ]
+                },
+                "layout": {
+                    "$ref": "#/definitions/models.SeatLayout"
+                },
                 "schedule_id": {
                     "type": "integer",
                     "example": 12
@@ -1704,6 +7687,12 @@ const docTemplate = `{
                         "A2",
                         "A3"
                     ]
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SeatMapSeat"
+                    }
                 }
             }
         },
@@ -1726,10 +7715,21 @@ const docTemplate = `{
                     "description": "akan diisi path setelah upload file",
                     "type": "string",
                     "example": "https://example.com/uploads/profile_101.png"
+                }
+            }
+        },
+        "models.UserPoints": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer",
+                    "example": 250
                 },
-                "virtual_account": {
-                    "type": "string",
-                    "example": "1234567890123456"
+                "ledger": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PointLedgerEntry"
+                    }
                 }
             }
         },
@@ -1767,10 +7767,137 @@ const docTemplate = `{
                 "role": {
                     "type": "string",
                     "example": "user"
+                }
+            }
+        },
+        "models.UserVA": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 150000
+                },
+                "bank": {
+                    "type": "string",
+                    "example": "BCA"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:30:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T19:45:00Z"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "0140000000000127"
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 501
+                }
+            }
+        },
+        "models.VATransferRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 150000
+                }
+            }
+        },
+        "models.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "cinema_name": {
+                    "type": "string",
+                    "example": "XXI Plaza Indonesia"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-20T17:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "movie_title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame"
+                },
+                "offer_expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T18:10:00Z"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "seat_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "D4",
+                        "D5"
+                    ]
+                },
+                "show_date": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "show_time": {
+                    "type": "string",
+                    "example": "19:30"
                 },
-                "virtual_account": {
+                "status": {
                     "type": "string",
-                    "example": "1234567890123456"
+                    "example": "offered"
+                }
+            }
+        },
+        "models.WaitlistRequest": {
+            "type": "object",
+            "required": [
+                "seat_count"
+            ],
+            "properties": {
+                "seat_count": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "payments.WebhookEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         }
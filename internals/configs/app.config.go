@@ -0,0 +1,100 @@
+package configs
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// envInt membaca environment variable bertipe angka, fallback ke nilai default kalau kosong / tidak valid
+func envInt(key string, fallback int) int {
+	val, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return val
+}
+
+// SeatHoldDuration lama kursi ditahan untuk user sebelum checkout (SEAT_HOLD_MINUTES, default 10 menit)
+func SeatHoldDuration() time.Duration {
+	return time.Duration(max(envInt("SEAT_HOLD_MINUTES", 10), 1)) * time.Minute
+}
+
+// SeatHoldMaxExtension total perpanjangan hold kursi di atas SeatHoldDuration sejak kursi pertama kali ditahan
+// (SEAT_HOLD_MAX_EXTENSION_MINUTES, default 10 menit)
+func SeatHoldMaxExtension() time.Duration {
+	return time.Duration(envInt("SEAT_HOLD_MAX_EXTENSION_MINUTES", 10)) * time.Minute
+}
+
+// OrderPaymentDuration batas waktu pembayaran order sejak dibuat (ORDER_PAYMENT_MINUTES, default 15 menit)
+func OrderPaymentDuration() time.Duration {
+	return time.Duration(max(envInt("ORDER_PAYMENT_MINUTES", 15), 1)) * time.Minute
+}
+
+// PaymentWebhookSecret secret HMAC untuk memverifikasi callback payment provider
+func PaymentWebhookSecret() string {
+	return os.Getenv("PAYMENT_WEBHOOK_SECRET")
+}
+
+// PaymentSandboxEnabled endpoint sandbox mock provider hanya aktif jika PAYMENT_SANDBOX=true
+func PaymentSandboxEnabled() bool {
+	return os.Getenv("PAYMENT_SANDBOX") == "true"
+}
+
+// OrderExpiryInterval jeda antar putaran worker expiry order (ORDER_EXPIRY_INTERVAL_SECONDS, default 30 detik)
+func OrderExpiryInterval() time.Duration {
+	return time.Duration(max(envInt("ORDER_EXPIRY_INTERVAL_SECONDS", 30), 1)) * time.Second
+}
+
+// OrderCancelCutoff batas terakhir user membatalkan order sebelum jam tayang (ORDER_CANCEL_CUTOFF_HOURS, default 2 jam)
+func OrderCancelCutoff() time.Duration {
+	return time.Duration(envInt("ORDER_CANCEL_CUTOFF_HOURS", 2)) * time.Hour
+}
+
+// TransferCutoff batas terakhir tiket dipindahtangankan ke akun lain sebelum jam tayang (TRANSFER_CUTOFF_HOURS, default 2 jam)
+func TransferCutoff() time.Duration {
+	return time.Duration(envInt("TRANSFER_CUTOFF_HOURS", 2)) * time.Hour
+}
+
+// IdempotencyTTL lama response disimpan untuk Idempotency-Key (IDEMPOTENCY_TTL_HOURS, default 24 jam)
+func IdempotencyTTL() time.Duration {
+	return time.Duration(envInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour
+}
+
+// LoyaltyEarnRate persentase total bayar yang dikreditkan sebagai poin saat order lunas (LOYALTY_EARN_RATE, default 10 persen)
+func LoyaltyEarnRate() int {
+	return envInt("LOYALTY_EARN_RATE", 10)
+}
+
+// LoyaltyPointValue potongan harga untuk setiap poin yang ditukar saat checkout (LOYALTY_POINT_VALUE, default 1)
+func LoyaltyPointValue() int {
+	return max(envInt("LOYALTY_POINT_VALUE", 1), 1)
+}
+
+// MaxSeatsPerOrder jumlah kursi maksimal dalam satu order (MAX_SEATS_PER_ORDER, default 8)
+func MaxSeatsPerOrder() int {
+	return max(envInt("MAX_SEATS_PER_ORDER", 8), 1)
+}
+
+// MaxSeatsPerUserSchedule jumlah kursi maksimal yang boleh di-hold atau dimiliki satu akun untuk satu schedule
+// dari semua ordernya (MAX_SEATS_PER_USER_SCHEDULE, default 10)
+func MaxSeatsPerUserSchedule() int {
+	return max(envInt("MAX_SEATS_PER_USER_SCHEDULE", 10), 1)
+}
+
+// AccessibleReleaseDuration jarak sebelum jam tayang ketika ruang kursi roda dan kursi pendamping yang belum terjual
+// dibuka untuk umum (ACCESSIBLE_RELEASE_MINUTES, default 60 menit)
+func AccessibleReleaseDuration() time.Duration {
+	return time.Duration(envInt("ACCESSIBLE_RELEASE_MINUTES", 60)) * time.Minute
+}
+
+// WaitlistOfferDuration lama kursi ditahan untuk user terdepan di waitlist sebelum ditawarkan ke antrean berikutnya
+// (WAITLIST_OFFER_MINUTES, default 15 menit)
+func WaitlistOfferDuration() time.Duration {
+	return time.Duration(max(envInt("WAITLIST_OFFER_MINUTES", 15), 1)) * time.Minute
+}
+
+// WaitlistInterval jeda antar putaran worker waitlist (WAITLIST_INTERVAL_SECONDS, default 30 detik)
+func WaitlistInterval() time.Duration {
+	return time.Duration(max(envInt("WAITLIST_INTERVAL_SECONDS", 30), 1)) * time.Second
+}
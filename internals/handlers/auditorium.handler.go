@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditoriumHandler struct {
+	Repo *repositories.AuditoriumRepo
+}
+
+func NewAuditoriumHandler(repo *repositories.AuditoriumRepo) *AuditoriumHandler {
+	return &AuditoriumHandler{Repo: repo}
+}
+
+// handleAuditoriumError memetakan error studio dari repository ke response http
+func handleAuditoriumError(ctx *gin.Context, err error) {
+	var inUse *repositories.AuditoriumInUseError
+	switch {
+	case errors.As(err, &inUse):
+		utils.HandleErrorWithData(ctx, http.StatusConflict, "Conflict", err.Error(), gin.H{"seat": inUse.Seats})
+	case errors.Is(err, repositories.ErrAuditoriumNotFound), errors.Is(err, repositories.ErrCinemaNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrAuditoriumExists):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, repositories.ErrInvalidLayout):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+	}
+}
+
+// GetAuditoriums godoc
+// @Summary Get auditoriums of a cinema
+// @Description Retrieve all auditoriums of a cinema with their seat layout
+// @Tags Master
+// @Produce json
+// @Param id path int true "Cinema ID"
+// @Success 200 {object} models.ResponseAuditoriums
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/cinemas/{id}/auditoriums [get]
+func (h *AuditoriumHandler) GetAuditoriums(ctx *gin.Context) {
+	cinemaID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || cinemaID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid cinema id")
+		return
+	}
+
+	auditoriums, err := h.Repo.GetByCinema(ctx.Request.Context(), cinemaID)
+	if err != nil {
+		handleAuditoriumError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.Auditorium]{
+		Success: true,
+		Message: "Success Load Auditoriums",
+		Data:    auditoriums,
+	})
+}
+
+// CreateAuditorium godoc
+// @Summary Create an auditorium
+// @Description Add an auditorium to a cinema. Seats are generated from the layout: rows are labelled A, B, C... and columns numbered from 1, gaps have no seat, disabled seats are never sold and class areas assign seat classes (couple seats are paired from the left).
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param id path int true "Cinema ID"
+// @Param request body models.AuditoriumRequest true "Auditorium"
+// @Success 201 {object} models.ResponseAuditorium
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Cinema not found"
+// @Failure 409 {object} models.ErrorResponse "Auditorium name already used"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/cinemas/{id}/auditoriums [post]
+func (h *AuditoriumHandler) CreateAuditorium(ctx *gin.Context) {
+	cinemaID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || cinemaID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid cinema id")
+		return
+	}
+
+	var req models.AuditoriumRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	auditorium, err := h.Repo.Create(ctx.Request.Context(), cinemaID, req)
+	if err != nil {
+		handleAuditoriumError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, models.Response[models.Auditorium]{
+		Success: true,
+		Message: "Success Create Auditorium",
+		Data:    *auditorium,
+	})
+}
+
+// GetAuditorium godoc
+// @Summary Get an auditorium
+// @Description Retrieve an auditorium with its seat layout
+// @Tags Master
+// @Produce json
+// @Param id path int true "Auditorium ID"
+// @Success 200 {object} models.ResponseAuditorium
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/auditoriums/{id} [get]
+func (h *AuditoriumHandler) GetAuditorium(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid auditorium id")
+		return
+	}
+
+	auditorium, err := h.Repo.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		handleAuditoriumError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.Auditorium]{
+		Success: true,
+		Message: "Success Load Auditorium",
+		Data:    *auditorium,
+	})
+}
+
+// UpdateAuditorium godoc
+// @Summary Update an auditorium
+// @Description Rename an auditorium or change its layout. Removing seats that are sold for upcoming schedules is rejected.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param id path int true "Auditorium ID"
+// @Param request body models.AuditoriumRequest true "Auditorium"
+// @Success 200 {object} models.ResponseAuditorium
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Seats sold on upcoming schedules"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/auditoriums/{id} [put]
+func (h *AuditoriumHandler) UpdateAuditorium(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid auditorium id")
+		return
+	}
+
+	var req models.AuditoriumRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	auditorium, err := h.Repo.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		handleAuditoriumError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.Auditorium]{
+		Success: true,
+		Message: "Success Update Auditorium",
+		Data:    *auditorium,
+	})
+}
+
+// DeleteAuditorium godoc
+// @Summary Delete an auditorium
+// @Description Soft delete an auditorium that has no upcoming schedules
+// @Tags Master
+// @Produce json
+// @Param id path int true "Auditorium ID"
+// @Success 200 {object} models.ResponseMessage
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Auditorium has upcoming schedules"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/auditoriums/{id} [delete]
+func (h *AuditoriumHandler) DeleteAuditorium(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid auditorium id")
+		return
+	}
+
+	if err := h.Repo.Delete(ctx.Request.Context(), id); err != nil {
+		handleAuditoriumError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[any]{
+		Success: true,
+		Message: "Success Delete Auditorium",
+	})
+}
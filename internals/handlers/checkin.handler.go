@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/Ntisrangga142/API_tickytiz/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type CheckinHandler struct {
+	Repo *repositories.CheckinRepo
+}
+
+func NewCheckinHandler(repo *repositories.CheckinRepo) *CheckinHandler {
+	return &CheckinHandler{Repo: repo}
+}
+
+// parseCheckinCode membaca hasil scan, isi QR code diverifikasi signature-nya sedangkan booking code dipakai apa adanya
+func parseCheckinCode(code string) (string, int, error) {
+	code = strings.TrimSpace(code)
+	if strings.HasPrefix(code, "TKT.") {
+		payload, err := pkg.VerifyTicket(code)
+		if err != nil {
+			return "", 0, err
+		}
+		return payload.BookingCode, payload.OrderID, nil
+	}
+	return strings.ToUpper(code), 0, nil
+}
+
+// CheckIn godoc
+// @Summary Check in a ticket
+// @Description Validate a scanned QR code or booking code and mark the tickets as used. The order must be paid and scheduled today at the staff's cinema.
+// @Tags Checkin
+// @Accept json
+// @Produce json
+// @Param request body models.CheckinRequest true "Scanned code"
+// @Success 200 {object} models.ResponseCheckin
+// @Failure 400 {object} models.ErrorResponse "Invalid ticket code"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Staff is not assigned to a cinema"
+// @Failure 404 {object} models.ErrorResponse "Ticket not found"
+// @Failure 409 {object} models.ErrorResponse "Ticket already used, not paid, not today, or for another cinema"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /checkin [post]
+func (h *CheckinHandler) CheckIn(ctx *gin.Context) {
+	staffID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.CheckinRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	bookingCode, orderID, err := parseCheckinCode(req.Code)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	res, err := h.Repo.CheckIn(ctx.Request.Context(), staffID, bookingCode, orderID)
+	if err != nil {
+		var usedErr *repositories.TicketUsedError
+		switch {
+		case errors.As(err, &usedErr):
+			utils.HandleErrorWithData(ctx, http.StatusConflict, "Conflict", err.Error(), models.TicketUsed{
+				OrderID:     usedErr.OrderID,
+				BookingCode: usedErr.BookingCode,
+				CheckinAt:   usedErr.CheckinAt,
+			})
+		case errors.Is(err, repositories.ErrStaffNotFound):
+			utils.HandleError(ctx, http.StatusForbidden, "Forbidden", err.Error())
+		case errors.Is(err, repositories.ErrTicketNotFound):
+			utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+		case errors.Is(err, repositories.ErrTicketNotPaid), errors.Is(err, repositories.ErrTicketWrongCinema), errors.Is(err, repositories.ErrTicketNotToday):
+			utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+		default:
+			utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.CheckinResponse]{
+		Success: true,
+		Message: "Success Check In",
+		Data:    *res,
+	})
+}
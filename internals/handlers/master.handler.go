@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
 	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -81,3 +84,48 @@ func (h *MasterHandler) GetCinemas(c *gin.Context) {
 		Data:    cinemas,
 	})
 }
+
+// UpdateSeatRules godoc
+// @Summary Update cinema seat rules
+// @Description Toggle the seat selection rules of a cinema. With no_orphan_seat enabled, holds and orders that would leave a single empty seat between occupied seats, aisles or gaps are rejected with alternative seats.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param id path int true "Cinema ID"
+// @Param request body models.CinemaSeatRulesRequest true "Seat rules"
+// @Success 200 {object} models.ResponseCinemaSeatRules
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Cinema not found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/cinemas/{id}/seat-rules [patch]
+func (h *MasterHandler) UpdateSeatRules(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid cinema id")
+		return
+	}
+
+	var req models.CinemaSeatRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	rules, err := h.Repo.UpdateSeatRules(ctx.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCinemaNotFound) {
+			utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+			return
+		}
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.CinemaSeatRules]{
+		Success: true,
+		Message: "Success Update Seat Rules",
+		Data:    *rules,
+	})
+}
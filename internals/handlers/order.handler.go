@@ -1,36 +1,47 @@
 package handlers
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/Ntisrangga142/API_tickytiz/internals/configs"
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
 	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
 	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"github.com/skip2/go-qrcode"
 )
 
 type OrderHandler struct {
-	Repo *repositories.OrderRepo
-	Rdb  *redis.Client
+	Repo      *repositories.OrderRepo
+	SeatRepo  *repositories.SeatRepository
+	Providers *payments.Registry
+	Rdb       *redis.Client
 }
 
-func NewOrderHandler(repo *repositories.OrderRepo, rdb *redis.Client) *OrderHandler {
-	return &OrderHandler{Repo: repo, Rdb: rdb}
+func NewOrderHandler(repo *repositories.OrderRepo, seatRepo *repositories.SeatRepository, providers *payments.Registry, rdb *redis.Client) *OrderHandler {
+	return &OrderHandler{Repo: repo, SeatRepo: seatRepo, Providers: providers, Rdb: rdb}
 }
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new order with seats and associate it with the logged-in user
+// @Description Create a new order with seats and associate it with the logged-in user. Every seat must be held by the user first. Points are redeemed after the promo discount and never exceed the remaining total. An order holds at most MAX_SEATS_PER_ORDER seats and an account at most MAX_SEATS_PER_USER_SCHEDULE seats per schedule. Wheelchair spaces need accessible set to true and companion seats must be ordered with the wheelchair space next to them.
 // @Tags Orders
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Retries with the same key and body replay the first response"
 // @Param request body models.OrderRequest true "Order request body"
 // @Success 200 {object} models.ResponseOrders
 // @Failure 400 {object} models.ErrorResponse "Bad Request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Seat already booked or not held by user"
+// @Failure 422 {object} models.ErrorResponse "Promo code rejected, not enough points, seat limit exceeded, selection leaves a single empty seat, or Idempotency-Key reused with a different body"
 // @Failure 500 {object} models.ErrorResponse "Internal Server Error"
 // @Security ApiKeyAuth
 // @Router /order [post]
@@ -41,29 +52,70 @@ func (h *OrderHandler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
+	h.checkout(ctx, userID, repositories.HoldOwnerUser(userID))
+}
+
+// CreateGuestOrder godoc
+// @Summary Create a guest order
+// @Description Checkout without an account. Seats must be held first through /schedule/seat/{id}/guest-hold with the same X-Guest-Token. Tickets can be retrieved later with POST /order/lookup. Points cannot be redeemed, and the promo per-user limit is counted per email.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param X-Guest-Token header string true "Guest session token used to hold the seats"
+// @Param Idempotency-Key header string false "Retries with the same key and body replay the first response"
+// @Param request body models.OrderRequest true "Order request body"
+// @Success 200 {object} models.ResponseOrders
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Missing guest token"
+// @Failure 409 {object} models.ErrorResponse "Seat already booked or not held by the guest session"
+// @Failure 422 {object} models.ErrorResponse "Promo code rejected, seat limit exceeded, selection leaves a single empty seat, or Idempotency-Key reused with a different body"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /order/guest [post]
+func (h *OrderHandler) CreateGuestOrder(ctx *gin.Context) {
+	h.checkout(ctx, 0, repositories.HoldOwnerGuest(ctx.GetString("guest_token")))
+}
+
+// checkout membuat order dari kursi yang sudah di-hold oleh owner, userID 0 untuk checkout tamu
+func (h *OrderHandler) checkout(ctx *gin.Context, userID int, owner string) {
 	var req models.OrderRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
 		return
 	}
 
-	res, err := h.Repo.CreateOrder(ctx.Request.Context(), req, userID)
+	// Kursi wajib sudah di-hold oleh pemesan ini
+	missing, err := h.SeatRepo.MissingHolds(ctx.Request.Context(), req.ScheduleID, owner, req.Seat)
 	if err != nil {
 		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
 		return
 	}
+	if len(missing) > 0 {
+		utils.HandleErrorWithData(ctx, http.StatusConflict, "Conflict", repositories.ErrHoldNotFound.Error(), models.SeatConflict{
+			ScheduleID: req.ScheduleID,
+			Seats:      missing,
+		})
+		return
+	}
+
+	if err := h.SeatRepo.CheckSeatRules(ctx.Request.Context(), req.ScheduleID, owner, req.Seat, nil); err != nil {
+		handleSeatError(ctx, err)
+		return
+	}
 
-	listSeat, err := h.Repo.CreateOrderDetails(ctx.Request.Context(), req, res.ID)
+	res, err := h.Repo.CreateOrder(ctx.Request.Context(), req, userID, time.Now().Add(configs.OrderPaymentDuration()), configs.LoyaltyPointValue(), seatLimits(), configs.AccessibleReleaseDuration())
 	if err != nil {
-		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		handleCheckoutError(ctx, err)
 		return
 	}
 
-	res.Seat = listSeat
+	if _, err := h.SeatRepo.ReleaseSeats(ctx.Request.Context(), req.ScheduleID, owner, res.Seat); err != nil {
+		log.Printf("Failed to release seat hold : %s\n", err.Error())
+	}
 
-	redisKey := fmt.Sprintf("Ntisrangga142-UserHistory-%d", userID)
-	if err := utils.InvalidateCache(ctx.Request.Context(), h.Rdb, redisKey); err != nil {
-		log.Printf("Failed to invalidate chace : %s\n", err.Error())
+	if userID != 0 {
+		if err := utils.InvalidateUserOrders(ctx.Request.Context(), h.Rdb, userID); err != nil {
+			log.Printf("Failed to invalidate chace : %s\n", err.Error())
+		}
 	}
 
 	ctx.JSON(http.StatusOK, models.Response[models.OrderResponse]{
@@ -72,3 +124,989 @@ func (h *OrderHandler) CreateOrder(ctx *gin.Context) {
 		Data:    *res,
 	})
 }
+
+// QuoteOrder godoc
+// @Summary Quote an order
+// @Description Calculate the price breakdown of the selected seats without booking anything. A promo code is validated and applied, except for the per-user limit which is checked when the order is created.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param request body models.OrderQuoteRequest true "Quote request body"
+// @Success 200 {object} models.ResponseOrderQuote
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 404 {object} models.ErrorResponse "Schedule not found"
+// @Failure 422 {object} models.ErrorResponse "Promo code rejected"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /order/quote [post]
+func (h *OrderHandler) QuoteOrder(ctx *gin.Context) {
+	var req models.OrderQuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	quote, err := h.Repo.Quote(ctx.Request.Context(), req, 0, configs.LoyaltyPointValue())
+	if err != nil {
+		handleCheckoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderQuote]{
+		Success: true,
+		Message: "Success Quote Order",
+		Data:    *quote,
+	})
+}
+
+// handleCheckoutError memetakan error quote / pembuatan order, promo dan poin yang ditolak dikembalikan beserta alasannya
+func handleCheckoutError(ctx *gin.Context, err error) {
+	var (
+		promo  *repositories.PromoError
+		points *repositories.InsufficientPointsError
+	)
+	switch {
+	case errors.As(err, &promo):
+		utils.HandleErrorWithData(ctx, http.StatusUnprocessableEntity, "Unprocessable Entity", err.Error(), models.PromoRejection{
+			Code:     promo.Code,
+			Reason:   promo.Reason,
+			MinSpend: promo.MinSpend,
+		})
+	case errors.As(err, &points):
+		utils.HandleErrorWithData(ctx, http.StatusUnprocessableEntity, "Unprocessable Entity", err.Error(), models.PointsRejection{
+			Balance:   points.Balance,
+			Requested: points.Requested,
+		})
+	case errors.Is(err, repositories.ErrGuestPoints):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		handleSeatError(ctx, err)
+	}
+}
+
+// handleOrderError memetakan error order dari repository ke response http
+func handleOrderError(ctx *gin.Context, err error) {
+	var transition *repositories.InvalidTransitionError
+	switch {
+	case errors.As(err, &transition):
+		utils.HandleErrorWithData(ctx, http.StatusConflict, "Conflict", err.Error(), models.OrderTransitionConflict{
+			From:    transition.From,
+			To:      transition.To,
+			Allowed: transition.Allowed(),
+		})
+	case errors.Is(err, repositories.ErrOrderNotFound), errors.Is(err, repositories.ErrPaymentNotFound), errors.Is(err, repositories.ErrRefundNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrPaymentDeadlinePassed), errors.Is(err, repositories.ErrCancelCutoffPassed), errors.Is(err, repositories.ErrOrderTransferred),
+		errors.Is(err, repositories.ErrOrderCheckedIn):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, repositories.ErrStatusNeedsCancel):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+	}
+}
+
+// GetOrder godoc
+// @Summary Get order detail
+// @Description Get the detail of an order: movie, schedule, cinema, seats, price breakdown, latest payment and ticket. Users can only read their own orders, admins can read any order.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} models.ResponseOrderDetail
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id} [get]
+func (h *OrderHandler) GetOrder(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+	role, err := utils.GetRoleFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	order, err := h.Repo.GetOrderDetail(ctx.Request.Context(), orderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+	if role != "admin" && (order.UserID == nil || *order.UserID != userID) {
+		utils.HandleError(ctx, http.StatusForbidden, "Forbidden", "you don't have access to this order")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderDetail]{
+		Success: true,
+		Message: "Success Load Order",
+		Data:    *order,
+	})
+}
+
+// GetQRCode godoc
+// @Summary Get order ticket QR code
+// @Description Render the signed ticket payload of an order as a PNG QR code, only the owner of the order can access it
+// @Tags Orders
+// @Produce png
+// @Param id path int true "Order ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/qrcode [get]
+func (h *OrderHandler) GetQRCode(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	ticket, err := h.Repo.GetTicket(ctx.Request.Context(), orderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+	if ticket.UserID == nil || *ticket.UserID != userID {
+		utils.HandleError(ctx, http.StatusForbidden, "Forbidden", "you don't have access to this order")
+		return
+	}
+
+	png, err := qrcode.Encode(ticket.QRCode, qrcode.Medium, 256)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// UpdateOrderStatus godoc
+// @Summary Update order status
+// @Description Move an order to awaiting_payment, paid or expired, only allowed transitions are accepted. Cancellations and refunds go through POST /order/{id}/admin-cancel so seats, promos and the refund are handled.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body models.OrderStatusRequest true "Target status"
+// @Success 200 {object} models.ResponseOrderStatus
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Invalid status transition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/status [patch]
+func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	var req models.OrderStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	order, err := h.Repo.UpdateStatus(ctx.Request.Context(), orderID, req.Status, configs.LoyaltyEarnRate())
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	if order.UserID != nil {
+		if err := utils.InvalidateUserOrders(ctx.Request.Context(), h.Rdb, *order.UserID); err != nil {
+			log.Printf("Failed to invalidate chace : %s\n", err.Error())
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderStatus]{
+		Success: true,
+		Message: "Success Update Order Status",
+		Data:    *order,
+	})
+}
+
+// GetOrderConflicts godoc
+// @Summary Get double-sold seat conflicts
+// @Description Retrieve the seats that were sold to more than one order before seats became unique per schedule. The migration kept the seat on kept_order_id and removed it from order_id, so these orders need a refund or a new seat.
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} models.ResponseOrderConflicts
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/conflicts [get]
+func (h *OrderHandler) GetOrderConflicts(ctx *gin.Context) {
+	conflicts, err := h.Repo.GetOrderConflicts(ctx.Request.Context())
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.OrderConflict]{
+		Success: true,
+		Message: "Success Load Order Conflicts",
+		Data:    conflicts,
+	})
+}
+
+// PayOrder godoc
+// @Summary Pay an order
+// @Description Create a charge at the payment provider mapped to the order's payment method and move the order to awaiting_payment
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} models.ResponsePayment
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Invalid status transition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/pay [post]
+func (h *OrderHandler) PayOrder(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	target, err := h.Repo.GetPaymentTarget(ctx.Request.Context(), orderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+	if target.UserID == nil || *target.UserID != userID {
+		utils.HandleError(ctx, http.StatusForbidden, "Forbidden", "you don't have access to this order")
+		return
+	}
+
+	payment, err := h.startPayment(ctx, target)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.Payment]{
+		Success: true,
+		Message: "Success Create Payment",
+		Data:    *payment,
+	})
+}
+
+// LookupOrder godoc
+// @Summary Look up an order
+// @Description Retrieve the tickets of an order with the email used at checkout and its booking code, no login required
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param request body models.OrderLookupRequest true "Email and booking code"
+// @Success 200 {object} models.ResponseOrderLookup
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /order/lookup [post]
+func (h *OrderHandler) LookupOrder(ctx *gin.Context) {
+	var req models.OrderLookupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	order, err := h.Repo.LookupOrder(ctx.Request.Context(), req.Email, req.BookingCode)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderHistory]{
+		Success: true,
+		Message: "Success Load Order",
+		Data:    *order,
+	})
+}
+
+// PayLookupOrder godoc
+// @Summary Pay a looked up order
+// @Description Create a charge for an order identified by the email used at checkout and its booking code, used by guest checkout
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param request body models.OrderLookupRequest true "Email and booking code"
+// @Success 200 {object} models.ResponsePayment
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Invalid status transition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /order/lookup/pay [post]
+func (h *OrderHandler) PayLookupOrder(ctx *gin.Context) {
+	var req models.OrderLookupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	order, err := h.Repo.LookupOrder(ctx.Request.Context(), req.Email, req.BookingCode)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	target, err := h.Repo.GetPaymentTarget(ctx.Request.Context(), order.OrderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	payment, err := h.startPayment(ctx, target)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.Payment]{
+		Success: true,
+		Message: "Success Create Payment",
+		Data:    *payment,
+	})
+}
+
+// startPayment memakai ulang charge yang masih aktif, atau membuat charge baru di provider milik payment method order
+func (h *OrderHandler) startPayment(ctx *gin.Context, target *models.OrderPaymentTarget) (*models.Payment, error) {
+	if target.Status == models.OrderStatusAwaitingPayment {
+		payment, err := h.Repo.GetActivePayment(ctx.Request.Context(), target.OrderID)
+		if err == nil {
+			return payment, nil
+		}
+		if !errors.Is(err, repositories.ErrPaymentNotFound) {
+			return nil, err
+		}
+	}
+
+	provider, err := h.Providers.Get(target.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	req := payments.ChargeRequest{
+		OrderID:         target.OrderID,
+		Amount:          target.TotalPrice,
+		PaymentMethodID: target.PaymentMethodID,
+		PaymentMethod:   target.PaymentMethod,
+		CustomerName:    target.Name,
+		CustomerEmail:   target.Email,
+	}
+	if target.ExpiresAt != nil {
+		req.ExpiresAt = *target.ExpiresAt
+	}
+
+	charge, err := provider.CreateCharge(ctx.Request.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := models.Payment{
+		OrderID:   target.OrderID,
+		Provider:  charge.Provider,
+		Reference: charge.Reference,
+		Amount:    charge.Amount,
+		Status:    charge.Status,
+		ExpiresAt: target.ExpiresAt,
+	}
+	if charge.PaymentURL != "" {
+		payment.PaymentURL = &charge.PaymentURL
+	}
+
+	return h.Repo.StartPayment(ctx.Request.Context(), payment)
+}
+
+// CancelOrder godoc
+// @Summary Cancel an order
+// @Description Cancel the user's own order up to ORDER_CANCEL_CUTOFF_HOURS before the showtime. Seats are released, loyalty points are reversed and paid orders are refunded. Tickets received by transfer cannot be cancelled because the refund goes to the original payer.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body models.OrderCancelRequest false "Cancellation reason"
+// @Success 200 {object} models.ResponseOrderCancellation
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Cutoff passed, ticket received by transfer, ticket already checked in or invalid status transition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/cancel [post]
+func (h *OrderHandler) CancelOrder(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.OrderCancelRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+	}
+
+	order, err := h.Repo.GetOrderStatus(ctx.Request.Context(), orderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		utils.HandleError(ctx, http.StatusForbidden, "Forbidden", "you don't have access to this order")
+		return
+	}
+
+	h.cancelOrder(ctx, orderID, userID, req.Reason, configs.OrderCancelCutoff())
+}
+
+// AdminCancelOrder godoc
+// @Summary Cancel any order
+// @Description Admin override to cancel any order regardless of the cutoff, including tickets received by transfer, for example when a screening is cancelled
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body models.OrderCancelRequest false "Cancellation reason"
+// @Success 200 {object} models.ResponseOrderCancellation
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Ticket already checked in or invalid status transition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/admin-cancel [post]
+func (h *OrderHandler) AdminCancelOrder(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	adminID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.OrderCancelRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+	}
+
+	h.cancelOrder(ctx, orderID, adminID, req.Reason, 0)
+}
+
+// cancelOrder membatalkan order lalu meneruskan refund ke provider. Refund yang gagal tetap tercatat pending
+// supaya bisa diproses ulang, order tetap cancelled.
+func (h *OrderHandler) cancelOrder(ctx *gin.Context, orderID, actorID int, reason string, cutoff time.Duration) {
+	res, err := h.Repo.CancelOrder(ctx.Request.Context(), orderID, actorID, reason, cutoff)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+
+	if refund := res.Refund; refund != nil && refund.Provider != nil && refund.PaymentReference != nil {
+		if completed, err := h.refund(ctx, refund); err != nil {
+			log.Printf("Failed to refund order %d : %s\n", orderID, err.Error())
+		} else {
+			res = completed
+		}
+	}
+
+	offerWaitlist(ctx, h.SeatRepo, res.Order.ScheduleID)
+
+	if res.Order.UserID != nil {
+		if err := utils.InvalidateUserOrders(ctx.Request.Context(), h.Rdb, *res.Order.UserID); err != nil {
+			log.Printf("Failed to invalidate chace : %s\n", err.Error())
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderCancellation]{
+		Success: true,
+		Message: "Success Cancel Order",
+		Data:    *res,
+	})
+}
+
+// refund mengembalikan dana lewat provider charge aslinya lalu mencatat hasilnya
+func (h *OrderHandler) refund(ctx *gin.Context, refund *models.Refund) (*models.OrderCancellation, error) {
+	return processRefund(ctx, h.Repo, h.Providers, refund)
+}
+
+// processRefund dipakai bersama oleh handler order dan webhook pembayaran
+func processRefund(ctx *gin.Context, repo *repositories.OrderRepo, providers *payments.Registry, refund *models.Refund) (*models.OrderCancellation, error) {
+	provider, err := providers.Get(*refund.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := provider.Refund(ctx.Request.Context(), *refund.PaymentReference, refund.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.CompleteRefund(ctx.Request.Context(), refund.ID, result.Status, &result.Reference)
+}
+
+// handleSeatChangeError memetakan error tukar kursi dari repository ke response http
+func handleSeatChangeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrOrderNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrSeatChangeStatus), errors.Is(err, repositories.ErrSeatChangeCutoffPassed), errors.Is(err, repositories.ErrSeatChangePending),
+		errors.Is(err, repositories.ErrOrderTransferred), errors.Is(err, repositories.ErrOrderCheckedIn):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, repositories.ErrSeatChangeCount), errors.Is(err, repositories.ErrSeatChangeUnchanged):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		handleSeatError(ctx, err)
+	}
+}
+
+// ChangeSeats godoc
+// @Summary Change the seats of an order
+// @Description Swap the seats of the user's own pending, awaiting payment or paid order for other available seats on the same schedule, up to ORDER_CANCEL_CUTOFF_HOURS before the showtime. The body lists the full new set of seats, seats that stay can be repeated. The whole swap fails if any new seat is already sold or held by someone else. The class price difference changes the order total: unpaid orders pay the new total and paid orders are refunded a lower amount. When a paid order moves to dearer seats the order is left unchanged and the new seats are held while a payment for the extra amount is created; the seats are swapped once that payment is paid and released if it fails or expires.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param Idempotency-Key header string false "Retries with the same key and body replay the first response"
+// @Param request body models.SeatChangeRequest true "New seats"
+// @Success 200 {object} models.ResponseSeatChange
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Seat already booked or held, cutoff passed, ticket received by transfer, ticket already checked in, a seat change already waiting for payment, or order status does not allow changes"
+// @Failure 422 {object} models.ErrorResponse "New seats leave a single empty seat"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/change-seats [post]
+func (h *OrderHandler) ChangeSeats(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.SeatChangeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	order, err := h.Repo.GetOrderStatus(ctx.Request.Context(), orderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		utils.HandleError(ctx, http.StatusForbidden, "Forbidden", "you don't have access to this order")
+		return
+	}
+
+	// kursi yang sedang di-hold orang lain sedang dalam proses checkout
+	owner := repositories.HoldOwnerUser(userID)
+	held, err := h.SeatRepo.ForeignHolds(ctx.Request.Context(), order.ScheduleID, owner, req.Seat)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+	if len(held) > 0 {
+		utils.HandleErrorWithData(ctx, http.StatusConflict, "Conflict", "seat is held by another customer", models.SeatConflict{
+			ScheduleID: order.ScheduleID,
+			Seats:      held,
+		})
+		return
+	}
+
+	// kursi order yang dilepas dianggap kosong saat aturan kursi kosong tunggal dijalankan pada kursi barunya
+	var from, to []string
+	for _, seat := range order.Seat {
+		if !slices.Contains(req.Seat, seat) {
+			from = append(from, seat)
+		}
+	}
+	for _, seat := range req.Seat {
+		if !slices.Contains(order.Seat, seat) {
+			to = append(to, seat)
+		}
+	}
+	if len(to) > 0 && len(from) == len(to) {
+		if err := h.SeatRepo.CheckSeatRules(ctx.Request.Context(), order.ScheduleID, owner, to, from); err != nil {
+			handleSeatChangeError(ctx, err)
+			return
+		}
+	}
+
+	expiresAt := time.Now().Add(configs.OrderPaymentDuration())
+	change, err := h.Repo.ChangeSeats(ctx.Request.Context(), orderID, userID, req.Seat, expiresAt, configs.OrderCancelCutoff(), configs.AccessibleReleaseDuration(), configs.LoyaltyEarnRate())
+	if err != nil {
+		handleSeatChangeError(ctx, err)
+		return
+	}
+
+	message := "Success Change Seats"
+	if change.ChangeStatus == models.SeatChangeStatusPending {
+		// order baru ditukar setelah selisihnya dibayar, sampai saat itu kursi baru ditahan atas nama tukar kursi ini
+		if err := h.SeatRepo.HoldSeatChange(ctx.Request.Context(), order.ScheduleID, owner, change.ID, change.ToSeats, expiresAt); err != nil {
+			h.closeSeatChange(ctx, order.ScheduleID, change.ID)
+			handleSeatChangeError(ctx, err)
+			return
+		}
+		if change.Payment, err = h.chargeSeatChange(ctx, change); err != nil {
+			h.closeSeatChange(ctx, order.ScheduleID, change.ID)
+			handleOrderError(ctx, err)
+			return
+		}
+		message = "Seat Change Waiting For Payment"
+	} else {
+		if _, err := h.SeatRepo.ReleaseSeats(ctx.Request.Context(), order.ScheduleID, owner, change.ToSeats); err != nil {
+			log.Printf("Failed to release seat hold : %s\n", err.Error())
+		}
+		offerWaitlist(ctx, h.SeatRepo, order.ScheduleID)
+
+		if refund := change.Refund; refund != nil && refund.Provider != nil && refund.PaymentReference != nil {
+			if completed, err := h.refund(ctx, refund); err != nil {
+				log.Printf("Failed to refund seat change %d : %s\n", change.ID, err.Error())
+			} else {
+				change.Refund = completed.Refund
+			}
+		}
+	}
+
+	if err := utils.InvalidateUserOrders(ctx.Request.Context(), h.Rdb, userID); err != nil {
+		log.Printf("Failed to invalidate chace : %s\n", err.Error())
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.SeatChange]{
+		Success: true,
+		Message: message,
+		Data:    *change,
+	})
+}
+
+// chargeSeatChange menagih selisih harga tukar kursi lewat provider milik payment method order
+func (h *OrderHandler) chargeSeatChange(ctx *gin.Context, change *models.SeatChange) (*models.Payment, error) {
+	target, err := h.Repo.GetPaymentTarget(ctx.Request.Context(), change.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := h.Providers.Get(target.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	charge, err := provider.CreateCharge(ctx.Request.Context(), payments.ChargeRequest{
+		OrderID:         target.OrderID,
+		Amount:          change.PriceDifference,
+		PaymentMethodID: target.PaymentMethodID,
+		PaymentMethod:   target.PaymentMethod,
+		CustomerName:    target.Name,
+		CustomerEmail:   target.Email,
+		ExpiresAt:       *change.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment := models.Payment{
+		OrderID:   target.OrderID,
+		Provider:  charge.Provider,
+		Reference: charge.Reference,
+		Amount:    charge.Amount,
+		Status:    charge.Status,
+		ExpiresAt: change.ExpiresAt,
+	}
+	if charge.PaymentURL != "" {
+		payment.PaymentURL = &charge.PaymentURL
+	}
+
+	return h.Repo.RecordSeatChangePayment(ctx.Request.Context(), change.ID, payment)
+}
+
+// closeSeatChange membatalkan tukar kursi pending yang kursinya gagal ditahan atau charge-nya gagal dibuat
+func (h *OrderHandler) closeSeatChange(ctx *gin.Context, scheduleID, changeID int) {
+	if _, err := h.SeatRepo.ReleaseSeats(ctx.Request.Context(), scheduleID, repositories.HoldOwnerSeatChange(changeID), nil); err != nil {
+		log.Printf("Failed to release seat hold : %s\n", err.Error())
+	}
+	if err := h.Repo.CloseSeatChange(ctx.Request.Context(), changeID); err != nil {
+		log.Printf("Failed to close seat change %d : %s\n", changeID, err.Error())
+	}
+}
+
+// handleTransferError memetakan error transfer tiket dari repository ke response http
+func handleTransferError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrOrderNotFound), errors.Is(err, repositories.ErrTransferNotFound), errors.Is(err, repositories.ErrTransferRecipientNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrTransferSelf):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	case errors.Is(err, repositories.ErrTransferOrderStatus),
+		errors.Is(err, repositories.ErrTransferCutoffPassed),
+		errors.Is(err, repositories.ErrTransferPending),
+		errors.Is(err, repositories.ErrTransferNotPending):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	default:
+		handleSeatError(ctx, err)
+	}
+}
+
+// transferID membaca id transfer dari path
+func transferID(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid transfer id")
+		return 0, false
+	}
+	return id, true
+}
+
+// TransferOrder godoc
+// @Summary Transfer a ticket to another user
+// @Description Offer the user's own paid, unused order to another registered user by email, up to TRANSFER_CUTOFF_HOURS before the showtime. The order moves only after the recipient accepts, an order can have one pending transfer at a time.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body models.OrderTransferRequest true "Recipient email"
+// @Success 201 {object} models.ResponseOrderTransfer
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Order or recipient not found"
+// @Failure 409 {object} models.ErrorResponse "Order not transferable, cutoff passed or transfer already pending"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /order/{id}/transfer [post]
+func (h *OrderHandler) TransferOrder(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || orderID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid order id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.OrderTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	order, err := h.Repo.GetOrderStatus(ctx.Request.Context(), orderID)
+	if err != nil {
+		handleOrderError(ctx, err)
+		return
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		utils.HandleError(ctx, http.StatusForbidden, "Forbidden", "you don't have access to this order")
+		return
+	}
+
+	transfer, err := h.Repo.CreateTransfer(ctx.Request.Context(), orderID, userID, req.Email, configs.TransferCutoff())
+	if err != nil {
+		handleTransferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, models.Response[models.OrderTransfer]{
+		Success: true,
+		Message: "Success Transfer Order",
+		Data:    *transfer,
+	})
+}
+
+// GetTransfers godoc
+// @Summary Get pending ticket transfers
+// @Description Retrieve the transfers sent and received by the user that are still waiting for the recipient
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} models.ResponseOrderTransfers
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /transfer [get]
+func (h *OrderHandler) GetTransfers(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	transfers, err := h.Repo.GetPendingTransfers(ctx.Request.Context(), userID)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.OrderTransfer]{
+		Success: true,
+		Message: "Success Load Transfers",
+		Data:    transfers,
+	})
+}
+
+// AcceptTransfer godoc
+// @Summary Accept a ticket transfer
+// @Description Accept a transfer sent to the user. The order moves to the user's account with the user's contact details, a new booking code and QR code are issued and the old ones stop working. The order's seats count towards the user's seat limit for the schedule.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.ResponseOrderTransfer
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Transfer already answered, order no longer transferable or cutoff passed"
+// @Failure 422 {object} models.ErrorResponse "Seat limit per account exceeded"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /transfer/{id}/accept [post]
+func (h *OrderHandler) AcceptTransfer(ctx *gin.Context) {
+	id, ok := transferID(ctx)
+	if !ok {
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	transfer, err := h.Repo.AcceptTransfer(ctx.Request.Context(), id, userID, configs.TransferCutoff(), configs.MaxSeatsPerUserSchedule())
+	if err != nil {
+		handleTransferError(ctx, err)
+		return
+	}
+
+	for _, uid := range []int{transfer.FromUserID, transfer.ToUserID} {
+		if err := utils.InvalidateUserHistory(ctx.Request.Context(), h.Rdb, uid); err != nil {
+			log.Printf("Failed to invalidate chace : %s\n", err.Error())
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderTransfer]{
+		Success: true,
+		Message: "Success Accept Transfer",
+		Data:    *transfer,
+	})
+}
+
+// DeclineTransfer godoc
+// @Summary Decline a ticket transfer
+// @Description Decline a transfer sent to the user, the order stays with the sender
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.ResponseOrderTransfer
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Transfer already answered"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /transfer/{id}/decline [post]
+func (h *OrderHandler) DeclineTransfer(ctx *gin.Context) {
+	h.closeTransfer(ctx, models.TransferStatusDeclined, "Success Decline Transfer")
+}
+
+// CancelTransfer godoc
+// @Summary Cancel a ticket transfer
+// @Description Withdraw a transfer the user sent before the recipient answers it
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} models.ResponseOrderTransfer
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Transfer already answered"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /transfer/{id}/cancel [post]
+func (h *OrderHandler) CancelTransfer(ctx *gin.Context) {
+	h.closeTransfer(ctx, models.TransferStatusCancelled, "Success Cancel Transfer")
+}
+
+// closeTransfer menutup transfer pending milik user dengan status declined atau cancelled
+func (h *OrderHandler) closeTransfer(ctx *gin.Context, status, message string) {
+	id, ok := transferID(ctx)
+	if !ok {
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	transfer, err := h.Repo.CloseTransfer(ctx.Request.Context(), id, userID, status)
+	if err != nil {
+		handleTransferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderTransfer]{
+		Success: true,
+		Message: message,
+		Data:    *transfer,
+	})
+}
@@ -1,11 +1,24 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
 	"net/http"
 
+	"github.com/Ntisrangga142/API_tickytiz/internals/configs"
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
 	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	errInvalidSignature = errors.New("invalid webhook signature")
+	errStatusMismatch   = errors.New("webhook status does not match provider")
 )
 
 type PaymentMethodHandler struct {
@@ -29,3 +42,237 @@ func (h *PaymentMethodHandler) GetAll(c *gin.Context) {
 		Data:    methods,
 	})
 }
+
+type PaymentHandler struct {
+	Repo      *repositories.OrderRepo
+	SeatRepo  *repositories.SeatRepository
+	Providers *payments.Registry
+	Mock      *payments.MockProvider
+	VA        *payments.VirtualAccountProvider
+	Rdb       *redis.Client
+}
+
+func NewPaymentHandler(repo *repositories.OrderRepo, seatRepo *repositories.SeatRepository, providers *payments.Registry, mock *payments.MockProvider, va *payments.VirtualAccountProvider, rdb *redis.Client) *PaymentHandler {
+	return &PaymentHandler{Repo: repo, SeatRepo: seatRepo, Providers: providers, Mock: mock, VA: va, Rdb: rdb}
+}
+
+// processWebhook memverifikasi signature, mencocokkan status ke provider, lalu mencatat hasil pembayaran
+func (h *PaymentHandler) processWebhook(ctx *gin.Context, body []byte, signature string) (*models.OrderStatus, error) {
+	if !payments.VerifySignature(configs.PaymentWebhookSecret(), body, signature) {
+		return nil, errInvalidSignature
+	}
+
+	var event payments.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+
+	provider, err := h.Providers.Get(event.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// jangan percaya payload begitu saja, cek ulang status ke provider
+	charge, err := provider.QueryStatus(ctx.Request.Context(), event.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if charge.Status != event.Status {
+		return nil, errStatusMismatch
+	}
+
+	order, seatChange, refund, err := h.Repo.SettlePayment(ctx.Request.Context(), event.Reference, event.Status, event.Amount, configs.LoyaltyEarnRate())
+	if err != nil {
+		return nil, err
+	}
+	if seatChange != nil {
+		h.settleSeatChange(ctx, seatChange)
+	}
+	if refund != nil {
+		// charge yang lunas setelah order tidak bisa dibayar langsung dikembalikan ke pembayar
+		if _, err := processRefund(ctx, h.Repo, h.Providers, refund); err != nil {
+			log.Printf("Failed to refund late payment of order %d : %s\n", refund.OrderID, err.Error())
+		}
+	}
+
+	if order.UserID != nil {
+		if err := utils.InvalidateUserOrders(ctx.Request.Context(), h.Rdb, *order.UserID); err != nil {
+			log.Printf("Failed to invalidate chace : %s\n", err.Error())
+		}
+	}
+
+	return order, nil
+}
+
+// settleSeatChange melepas hold kursi baru tukar kursi yang sudah selesai dan mengembalikan charge yang tidak jadi dipakai.
+// Kursi lama yang lepas setelah penukaran diterapkan ditawarkan ke waitlist.
+func (h *PaymentHandler) settleSeatChange(ctx *gin.Context, settlement *repositories.SeatChangeSettlement) {
+	if settlement.Status == models.SeatChangeStatusPending {
+		return
+	}
+
+	if _, err := h.SeatRepo.ReleaseSeats(ctx.Request.Context(), settlement.ScheduleID, repositories.HoldOwnerSeatChange(settlement.ChangeID), nil); err != nil {
+		log.Printf("Failed to release seat hold : %s\n", err.Error())
+	}
+	offerWaitlist(ctx, h.SeatRepo, settlement.ScheduleID)
+
+	if refund := settlement.Refund; refund != nil && refund.Provider != nil && refund.PaymentReference != nil {
+		if _, err := processRefund(ctx, h.Repo, h.Providers, refund); err != nil {
+			log.Printf("Failed to refund seat change %d : %s\n", settlement.ChangeID, err.Error())
+		}
+	}
+}
+
+// handlePaymentError memetakan error webhook ke response http
+func handlePaymentError(ctx *gin.Context, err error) {
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, errInvalidSignature):
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+	case errors.As(err, &syntaxErr), errors.Is(err, errStatusMismatch), errors.Is(err, repositories.ErrPaymentAmountMismatch),
+		errors.Is(err, payments.ErrInvalidVirtualAccount), errors.Is(err, payments.ErrTransferAmountMismatch):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	case errors.Is(err, payments.ErrVirtualAccountClosed), errors.Is(err, payments.ErrVirtualAccountNotPending):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, payments.ErrProviderNotFound), errors.Is(err, payments.ErrChargeNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	default:
+		handleOrderError(ctx, err)
+	}
+}
+
+// Webhook godoc
+// @Summary Payment webhook
+// @Description Callback from the payment provider, the raw body must be signed with HMAC-SHA256 in the X-Signature header. A paid charge for an order that expired, was cancelled or is already paid is recorded and refunded
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param X-Signature header string true "Hex HMAC-SHA256 of the body"
+// @Param request body payments.WebhookEvent true "Webhook event"
+// @Success 200 {object} models.ResponseOrderStatus
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Invalid signature"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Invalid status transition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /payment/webhook [post]
+func (h *PaymentHandler) Webhook(ctx *gin.Context) {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	order, err := h.processWebhook(ctx, body, ctx.GetHeader("X-Signature"))
+	if err != nil {
+		handlePaymentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderStatus]{
+		Success: true,
+		Message: "Success Process Payment",
+		Data:    *order,
+	})
+}
+
+// Sandbox godoc
+// @Summary Drive a mock payment
+// @Description Change the status of a mock charge and deliver the signed webhook, only available when PAYMENT_SANDBOX=true
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param reference path string true "Charge reference"
+// @Param request body models.PaymentSandboxRequest true "Target charge status"
+// @Success 200 {object} models.ResponseOrderStatus
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /payment/sandbox/{reference} [post]
+func (h *PaymentHandler) Sandbox(ctx *gin.Context) {
+	if !configs.PaymentSandboxEnabled() {
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", "payment sandbox is disabled")
+		return
+	}
+
+	var req models.PaymentSandboxRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	event, err := h.Mock.Simulate(ctx.Request.Context(), ctx.Param("reference"), req.Status)
+	if err != nil {
+		handlePaymentError(ctx, err)
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	order, err := h.processWebhook(ctx, body, payments.Sign(configs.PaymentWebhookSecret(), body))
+	if err != nil {
+		handlePaymentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderStatus]{
+		Success: true,
+		Message: "Success Simulate Payment",
+		Data:    *order,
+	})
+}
+
+// SimulateTransfer godoc
+// @Summary Simulate a bank transfer to a virtual account
+// @Description Pay a virtual account as if the bank received a transfer, then deliver the signed webhook that marks the order paid. The amount must match the virtual account exactly. Only available when PAYMENT_SANDBOX=true
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param number path string true "Virtual account number"
+// @Param request body models.VATransferRequest true "Transfer amount"
+// @Success 200 {object} models.ResponseOrderStatus
+// @Failure 400 {object} models.ErrorResponse "Invalid number or amount mismatch"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Virtual account already paid or expired"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /payment/va/{number}/transfer [post]
+func (h *PaymentHandler) SimulateTransfer(ctx *gin.Context) {
+	if !configs.PaymentSandboxEnabled() {
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", "payment sandbox is disabled")
+		return
+	}
+
+	var req models.VATransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	event, err := h.VA.Transfer(ctx.Request.Context(), ctx.Param("number"), req.Amount)
+	if err != nil {
+		handlePaymentError(ctx, err)
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	order, err := h.processWebhook(ctx, body, payments.Sign(configs.PaymentWebhookSecret(), body))
+	if err != nil {
+		handlePaymentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.OrderStatus]{
+		Success: true,
+		Message: "Success Simulate Transfer",
+		Data:    *order,
+	})
+}
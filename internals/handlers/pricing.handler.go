@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+type PricingHandler struct {
+	Repo *repositories.PricingRepo
+	Rdb  *redis.Client
+}
+
+func NewPricingHandler(repo *repositories.PricingRepo, rdb *redis.Client) *PricingHandler {
+	return &PricingHandler{Repo: repo, Rdb: rdb}
+}
+
+// handlePricingError memetakan error pricing rule dan hari libur dari repository ke response http
+func handlePricingError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrPricingRuleNotFound),
+		errors.Is(err, repositories.ErrHolidayNotFound),
+		errors.Is(err, repositories.ErrScheduleNotFound),
+		errors.Is(err, repositories.ErrCinemaNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrHolidayExists):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, repositories.ErrInvalidPricingRule):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+	}
+}
+
+// invalidateSchedules harga di /schedule/:id ikut berubah setiap rule atau hari libur diubah
+func (h *PricingHandler) invalidateSchedules(ctx *gin.Context) {
+	if err := utils.InvalidateSchedules(ctx.Request.Context(), h.Rdb); err != nil {
+		log.Println("Failed to invalidate schedule cache:", err)
+	}
+}
+
+// GetPricingRules godoc
+// @Summary Get pricing rules
+// @Description Retrieve all pricing rules ordered by priority
+// @Tags Master
+// @Produce json
+// @Success 200 {object} models.ResponsePricingRules
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/pricing-rules [get]
+func (h *PricingHandler) GetPricingRules(ctx *gin.Context) {
+	rules, err := h.Repo.GetRules(ctx.Request.Context())
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.PricingRule]{
+		Success: true,
+		Message: "Success Load Pricing Rules",
+		Data:    rules,
+	})
+}
+
+// CreatePricingRule godoc
+// @Summary Create a pricing rule
+// @Description Add a base price rule. Empty conditions match every schedule. When several rules match, the highest priority wins, then the rule with the most conditions, then the newest rule.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param request body models.PricingRuleRequest true "Pricing rule"
+// @Success 201 {object} models.ResponsePricingRule
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Cinema not found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/pricing-rules [post]
+func (h *PricingHandler) CreatePricingRule(ctx *gin.Context) {
+	var req models.PricingRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	rule, err := h.Repo.CreateRule(ctx.Request.Context(), req)
+	if err != nil {
+		handlePricingError(ctx, err)
+		return
+	}
+	h.invalidateSchedules(ctx)
+
+	ctx.JSON(http.StatusCreated, models.Response[models.PricingRule]{
+		Success: true,
+		Message: "Success Create Pricing Rule",
+		Data:    *rule,
+	})
+}
+
+// UpdatePricingRule godoc
+// @Summary Update a pricing rule
+// @Description Replace the conditions and price of a pricing rule. Existing orders keep the price they were sold at.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param id path int true "Pricing rule ID"
+// @Param request body models.PricingRuleRequest true "Pricing rule"
+// @Success 200 {object} models.ResponsePricingRule
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/pricing-rules/{id} [put]
+func (h *PricingHandler) UpdatePricingRule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid pricing rule id")
+		return
+	}
+
+	var req models.PricingRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	rule, err := h.Repo.UpdateRule(ctx.Request.Context(), id, req)
+	if err != nil {
+		handlePricingError(ctx, err)
+		return
+	}
+	h.invalidateSchedules(ctx)
+
+	ctx.JSON(http.StatusOK, models.Response[models.PricingRule]{
+		Success: true,
+		Message: "Success Update Pricing Rule",
+		Data:    *rule,
+	})
+}
+
+// DeletePricingRule godoc
+// @Summary Delete a pricing rule
+// @Description Remove a pricing rule, schedules fall back to the next matching rule or the cinema price
+// @Tags Master
+// @Produce json
+// @Param id path int true "Pricing rule ID"
+// @Success 200 {object} models.ResponseMessage
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/pricing-rules/{id} [delete]
+func (h *PricingHandler) DeletePricingRule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid pricing rule id")
+		return
+	}
+
+	if err := h.Repo.DeleteRule(ctx.Request.Context(), id); err != nil {
+		handlePricingError(ctx, err)
+		return
+	}
+	h.invalidateSchedules(ctx)
+
+	ctx.JSON(http.StatusOK, models.Response[any]{
+		Success: true,
+		Message: "Success Delete Pricing Rule",
+	})
+}
+
+// GetHolidays godoc
+// @Summary Get holidays
+// @Description Retrieve the holiday calendar used by holiday pricing rules
+// @Tags Master
+// @Produce json
+// @Success 200 {object} models.ResponseHolidays
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/holidays [get]
+func (h *PricingHandler) GetHolidays(ctx *gin.Context) {
+	holidays, err := h.Repo.GetHolidays(ctx.Request.Context())
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.Holiday]{
+		Success: true,
+		Message: "Success Load Holidays",
+		Data:    holidays,
+	})
+}
+
+// CreateHoliday godoc
+// @Summary Add a holiday
+// @Description Register a date in the holiday calendar
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param request body models.HolidayRequest true "Holiday"
+// @Success 201 {object} models.ResponseHoliday
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Date already registered"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/holidays [post]
+func (h *PricingHandler) CreateHoliday(ctx *gin.Context) {
+	var req models.HolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	holiday, err := h.Repo.CreateHoliday(ctx.Request.Context(), req)
+	if err != nil {
+		handlePricingError(ctx, err)
+		return
+	}
+	h.invalidateSchedules(ctx)
+
+	ctx.JSON(http.StatusCreated, models.Response[models.Holiday]{
+		Success: true,
+		Message: "Success Create Holiday",
+		Data:    *holiday,
+	})
+}
+
+// DeleteHoliday godoc
+// @Summary Delete a holiday
+// @Description Remove a date from the holiday calendar
+// @Tags Master
+// @Produce json
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} models.ResponseMessage
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/holidays/{id} [delete]
+func (h *PricingHandler) DeleteHoliday(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid holiday id")
+		return
+	}
+
+	if err := h.Repo.DeleteHoliday(ctx.Request.Context(), id); err != nil {
+		handlePricingError(ctx, err)
+		return
+	}
+	h.invalidateSchedules(ctx)
+
+	ctx.JSON(http.StatusOK, models.Response[any]{
+		Success: true,
+		Message: "Success Delete Holiday",
+	})
+}
+
+// PreviewPricing godoc
+// @Summary Preview schedule pricing
+// @Description Explain the base price of a schedule: every active rule is evaluated in priority order with the reasons it did not match, and the seat class prices that follow from the applied rule
+// @Tags Master
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} models.ResponsePricingPreview
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/schedules/{id}/pricing [get]
+func (h *PricingHandler) PreviewPricing(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid schedule id")
+		return
+	}
+
+	preview, err := h.Repo.Preview(ctx.Request.Context(), id)
+	if err != nil {
+		handlePricingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.PricingPreview]{
+		Success: true,
+		Message: "Success Preview Pricing",
+		Data:    *preview,
+	})
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PromoHandler struct {
+	Repo *repositories.PromoRepo
+}
+
+func NewPromoHandler(repo *repositories.PromoRepo) *PromoHandler {
+	return &PromoHandler{Repo: repo}
+}
+
+// handlePromoError memetakan error promo code dari repository ke response http
+func handlePromoError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrPromoNotFound), errors.Is(err, repositories.ErrPromoTargetNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrPromoExists):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	default:
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+	}
+}
+
+// GetPromoCodes godoc
+// @Summary Get promo codes
+// @Description Retrieve all promo codes with their restrictions and active usage count
+// @Tags Master
+// @Produce json
+// @Success 200 {object} models.ResponsePromoCodes
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/promo-codes [get]
+func (h *PromoHandler) GetPromoCodes(ctx *gin.Context) {
+	promos, err := h.Repo.GetAll(ctx.Request.Context())
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.PromoCode]{
+		Success: true,
+		Message: "Success Load Promo Codes",
+		Data:    promos,
+	})
+}
+
+// GetPromoCode godoc
+// @Summary Get a promo code
+// @Tags Master
+// @Produce json
+// @Param id path int true "Promo code ID"
+// @Success 200 {object} models.ResponsePromoCode
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/promo-codes/{id} [get]
+func (h *PromoHandler) GetPromoCode(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid promo code id")
+		return
+	}
+
+	promo, err := h.Repo.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		handlePromoError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.PromoCode]{
+		Success: true,
+		Message: "Success Load Promo Code",
+		Data:    *promo,
+	})
+}
+
+// CreatePromoCode godoc
+// @Summary Create a promo code
+// @Description Add a percentage or fixed discount code. Empty movie, cinema or payment method lists mean the code is valid for all of them.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param request body models.PromoCodeRequest true "Promo code"
+// @Success 201 {object} models.ResponsePromoCode
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Movie, cinema or payment method not found"
+// @Failure 409 {object} models.ErrorResponse "Code already used"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/promo-codes [post]
+func (h *PromoHandler) CreatePromoCode(ctx *gin.Context) {
+	var req models.PromoCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+	if req.DiscountType == models.PromoDiscountPercent && req.DiscountValue > 100 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "percent discount must be at most 100")
+		return
+	}
+
+	promo, err := h.Repo.Create(ctx.Request.Context(), req)
+	if err != nil {
+		handlePromoError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, models.Response[models.PromoCode]{
+		Success: true,
+		Message: "Success Create Promo Code",
+		Data:    *promo,
+	})
+}
+
+// UpdatePromoCode godoc
+// @Summary Update a promo code
+// @Description Replace a promo code and its restrictions. Orders that already used the code keep their discount.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param id path int true "Promo code ID"
+// @Param request body models.PromoCodeRequest true "Promo code"
+// @Success 200 {object} models.ResponsePromoCode
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 409 {object} models.ErrorResponse "Code already used"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/promo-codes/{id} [put]
+func (h *PromoHandler) UpdatePromoCode(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid promo code id")
+		return
+	}
+
+	var req models.PromoCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+	if req.DiscountType == models.PromoDiscountPercent && req.DiscountValue > 100 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "percent discount must be at most 100")
+		return
+	}
+
+	promo, err := h.Repo.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		handlePromoError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.PromoCode]{
+		Success: true,
+		Message: "Success Update Promo Code",
+		Data:    *promo,
+	})
+}
+
+// DeletePromoCode godoc
+// @Summary Delete a promo code
+// @Tags Master
+// @Produce json
+// @Param id path int true "Promo code ID"
+// @Success 200 {object} models.ResponseMessage
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/promo-codes/{id} [delete]
+func (h *PromoHandler) DeletePromoCode(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid promo code id")
+		return
+	}
+
+	if err := h.Repo.Delete(ctx.Request.Context(), id); err != nil {
+		handlePromoError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[any]{
+		Success: true,
+		Message: "Success Delete Promo Code",
+	})
+}
@@ -29,7 +29,7 @@ func NewScheduleHandler(repo *repositories.ScheduleRepo, rdb *redis.Client) *Sch
 // @Accept json
 // @Produce json
 // @Param id path int true "Movie ID"
-// @Success 200 {object} models.Response[models.ScheduleResponse]
+// @Success 200 {object} models.ResponseSchedule
 // @Failure 400 {object} models.ErrorResponse "Bad Request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 500 {object} models.ErrorResponse "Internal Server Error"
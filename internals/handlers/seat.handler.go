@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 
+	"github.com/Ntisrangga142/API_tickytiz/internals/configs"
 	models "github.com/Ntisrangga142/API_tickytiz/internals/models"
 	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
 	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
@@ -18,9 +21,69 @@ func NewSeatHandler(repo *repositories.SeatRepository) *SeatHandler {
 	return &SeatHandler{Repo: repo}
 }
 
+// seatLimits batas kursi per order dan per akun untuk satu schedule dari konfigurasi
+func seatLimits() repositories.SeatLimits {
+	return repositories.SeatLimits{
+		PerOrder:        configs.MaxSeatsPerOrder(),
+		PerUserSchedule: configs.MaxSeatsPerUserSchedule(),
+	}
+}
+
+// handleSeatError memetakan error kursi dari repository ke response http
+func handleSeatError(ctx *gin.Context, err error) {
+	var (
+		conflict *repositories.SeatConflictError
+		limit    *repositories.SeatLimitError
+		orphan   *repositories.OrphanSeatError
+	)
+	switch {
+	case errors.As(err, &orphan):
+		utils.HandleErrorWithData(ctx, http.StatusUnprocessableEntity, "Unprocessable Entity", err.Error(), models.SeatRuleViolation{
+			Reason:      models.SeatRuleOrphanSeat,
+			ScheduleID:  orphan.ScheduleID,
+			OrphanSeats: orphan.Seats,
+			Suggestions: orphan.Suggestions,
+		})
+	case errors.As(err, &limit):
+		utils.HandleErrorWithData(ctx, http.StatusUnprocessableEntity, "Unprocessable Entity", err.Error(), models.SeatLimitExceeded{
+			Scope:      limit.Scope,
+			ScheduleID: limit.ScheduleID,
+			Limit:      limit.Limit,
+			Used:       limit.Used,
+			Requested:  limit.Requested,
+		})
+	case errors.As(err, &conflict):
+		utils.HandleErrorWithData(ctx, http.StatusConflict, "Conflict", err.Error(), models.SeatConflict{
+			ScheduleID: conflict.ScheduleID,
+			Seats:      conflict.Seats,
+		})
+	case errors.Is(err, repositories.ErrScheduleNotFound), errors.Is(err, repositories.ErrHoldNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrHoldLimitReached):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, repositories.ErrSeatNotFound), errors.Is(err, repositories.ErrSeatDisabled), errors.Is(err, repositories.ErrSeatPairRequired),
+		errors.Is(err, repositories.ErrWheelchairSeat), errors.Is(err, repositories.ErrCompanionSeat):
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+	}
+}
+
+// holdOwner menentukan pemilik hold: sesi tamu bila route memakai GuestSession (userID 0), selain itu user yang login
+func holdOwner(ctx *gin.Context) (string, int, error) {
+	if token := ctx.GetString("guest_token"); token != "" {
+		return repositories.HoldOwnerGuest(token), 0, nil
+	}
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	return repositories.HoldOwnerUser(userID), userID, nil
+}
+
 // GetSoldSeats godoc
-// @Summary Get sold seats
-// @Description Retrieve all sold seats for a specific schedule
+// @Summary Get seat map
+// @Description Retrieve the auditorium seat map of a schedule with the status and accessibility attribute (wheelchair or companion) of each seat, plus the sold and currently held seat ids. accessible_released tells whether unsold wheelchair spaces and companion seats are already open to everyone.
 // @Tags Schedules
 // @Accept json
 // @Produce json
@@ -28,6 +91,7 @@ func NewSeatHandler(repo *repositories.SeatRepository) *SeatHandler {
 // @Success 200 {object} models.ResponseSeats
 // @Failure 400 {object} models.ErrorResponse "Bad Request"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Schedule not found"
 // @Failure 500 {object} models.ErrorResponse "Internal Server Error"
 // @Security ApiKeyAuth
 // @Router /schedule/seat/{id} [get]
@@ -39,18 +103,292 @@ func (h *SeatHandler) GetSoldSeats(ctx *gin.Context) {
 		return
 	}
 
-	seats, err := h.Repo.GetSoldSeats(ctx.Request.Context(), scheduleID)
+	seatMap, err := h.Repo.GetSeatMap(ctx.Request.Context(), scheduleID, configs.AccessibleReleaseDuration())
 	if err != nil {
-		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		handleSeatError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, models.Response[models.SeatResponse]{
 		Success: true,
 		Message: "Success Load Seats Sold",
-		Data: models.SeatResponse{
+		Data:    *seatMap,
+	})
+}
+
+// HoldSeats godoc
+// @Summary Hold seats
+// @Description Reserve seats of a schedule for the logged-in user, or for the guest session in X-Guest-Token on guest-hold, for a limited time before checkout. Wheelchair spaces need accessible set to true and companion seats must be held together with the wheelchair space next to them, until they are released to general sale shortly before the showtime.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param X-Guest-Token header string false "Guest session token, required on guest-hold"
+// @Param request body models.SeatHoldRequest true "Seats to hold"
+// @Success 200 {object} models.ResponseSeatHold
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Schedule not found"
+// @Failure 409 {object} models.ErrorResponse "Seat already sold or held"
+// @Failure 422 {object} models.ErrorResponse "Seat limit per order or per account exceeded, or selection leaves a single empty seat"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /schedule/seat/{id}/hold [post]
+// @Router /schedule/seat/{id}/guest-hold [post]
+func (h *SeatHandler) HoldSeats(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || scheduleID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid schedule id")
+		return
+	}
+
+	owner, userID, err := holdOwner(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.SeatHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	expiresAt, err := h.Repo.HoldSeats(ctx.Request.Context(), scheduleID, owner, userID, req.Seat, req.Accessible, seatLimits(), configs.SeatHoldDuration(), configs.AccessibleReleaseDuration())
+	if err != nil {
+		handleSeatError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.SeatHoldResponse]{
+		Success: true,
+		Message: "Success Hold Seats",
+		Data: models.SeatHoldResponse{
+			ScheduleID: scheduleID,
+			Seat:       req.Seat,
+			ExpiresAt:  expiresAt,
+		},
+	})
+}
+
+// ReleaseSeats godoc
+// @Summary Release held seats
+// @Description Release seats held by the logged-in user or guest session, all of them when no seat is given
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param X-Guest-Token header string false "Guest session token, required on guest-hold"
+// @Param request body models.SeatHoldUpdateRequest false "Seats to release"
+// @Success 200 {object} models.ResponseSeatRelease
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /schedule/seat/{id}/hold [delete]
+// @Router /schedule/seat/{id}/guest-hold [delete]
+func (h *SeatHandler) ReleaseSeats(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || scheduleID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid schedule id")
+		return
+	}
+
+	owner, _, err := holdOwner(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.SeatHoldUpdateRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+	}
+
+	released, err := h.Repo.ReleaseSeats(ctx.Request.Context(), scheduleID, owner, req.Seat)
+	if err != nil {
+		handleSeatError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.SeatReleaseResponse]{
+		Success: true,
+		Message: "Success Release Seats",
+		Data: models.SeatReleaseResponse{
+			ScheduleID: scheduleID,
+			Seat:       released,
+		},
+	})
+}
+
+// ExtendHold godoc
+// @Summary Extend held seats
+// @Description Extend the hold of seats held by the logged-in user or guest session, all of them when no seat is given. A hold cannot last longer than SEAT_HOLD_MINUTES plus SEAT_HOLD_MAX_EXTENSION_MINUTES from when the seat was first held.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param X-Guest-Token header string false "Guest session token, required on guest-hold"
+// @Param request body models.SeatHoldUpdateRequest false "Seats to extend"
+// @Success 200 {object} models.ResponseSeatHold
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Hold not found or expired"
+// @Failure 409 {object} models.ErrorResponse "Hold extension limit reached"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /schedule/seat/{id}/hold [patch]
+// @Router /schedule/seat/{id}/guest-hold [patch]
+func (h *SeatHandler) ExtendHold(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || scheduleID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid schedule id")
+		return
+	}
+
+	owner, _, err := holdOwner(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.SeatHoldUpdateRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+	}
+
+	extended, expiresAt, err := h.Repo.ExtendHold(ctx.Request.Context(), scheduleID, owner, req.Seat, configs.SeatHoldDuration(), configs.SeatHoldMaxExtension())
+	if err != nil {
+		handleSeatError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.SeatHoldResponse]{
+		Success: true,
+		Message: "Success Extend Seats Hold",
+		Data: models.SeatHoldResponse{
 			ScheduleID: scheduleID,
-			Seat:       seats.ID,
+			Seat:       extended,
+			ExpiresAt:  expiresAt,
 		},
 	})
 }
+
+// handleWaitlistError memetakan error waitlist dari repository ke response http
+func handleWaitlistError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrWaitlistNotFound):
+		utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, repositories.ErrWaitlistNotSoldOut), errors.Is(err, repositories.ErrWaitlistJoined), errors.Is(err, repositories.ErrWaitlistClosed):
+		utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+	default:
+		handleSeatError(ctx, err)
+	}
+}
+
+// JoinWaitlist godoc
+// @Summary Join the waitlist of a schedule
+// @Description Queue the logged-in user for seat_count seats of a schedule that does not have enough seats left. When seats free up through expiry or cancellation, the first user in line gets the seats held for WAITLIST_OFFER_MINUTES and a notification, then books them through the normal order endpoint. An offer that is not booked in time passes to the next user in line.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param request body models.WaitlistRequest true "Number of seats"
+// @Success 201 {object} models.ResponseWaitlistEntry
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Schedule not found"
+// @Failure 409 {object} models.ErrorResponse "Seats still available, already on the waitlist or schedule started"
+// @Failure 422 {object} models.ErrorResponse "Seat limit exceeded"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /schedule/seat/{id}/waitlist [post]
+func (h *SeatHandler) JoinWaitlist(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || scheduleID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid schedule id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.WaitlistRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	entry, err := h.Repo.JoinWaitlist(ctx.Request.Context(), scheduleID, userID, req.SeatCount, seatLimits(), configs.AccessibleReleaseDuration())
+	if err != nil {
+		handleWaitlistError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, models.Response[models.WaitlistEntry]{
+		Success: true,
+		Message: "Success Join Waitlist",
+		Data:    *entry,
+	})
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave the waitlist of a schedule
+// @Description Remove the logged-in user from the waitlist of a schedule. Seats currently offered to the user are released and offered to the next user in line.
+// @Tags Schedules
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} models.ResponseWaitlistEntry
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not on the waitlist"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /schedule/seat/{id}/waitlist [delete]
+func (h *SeatHandler) LeaveWaitlist(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || scheduleID < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid schedule id")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	entry, err := h.Repo.LeaveWaitlist(ctx.Request.Context(), scheduleID, userID)
+	if err != nil {
+		handleWaitlistError(ctx, err)
+		return
+	}
+	offerWaitlist(ctx, h.Repo, scheduleID)
+
+	ctx.JSON(http.StatusOK, models.Response[models.WaitlistEntry]{
+		Success: true,
+		Message: "Success Leave Waitlist",
+		Data:    *entry,
+	})
+}
+
+// offerWaitlist langsung menawarkan kursi yang baru lepas ke antrean schedule tanpa menunggu putaran worker
+func offerWaitlist(ctx *gin.Context, repo *repositories.SeatRepository, scheduleID int) {
+	offers, err := repo.OfferWaitlist(ctx.Request.Context(), scheduleID, configs.WaitlistOfferDuration(), configs.AccessibleReleaseDuration())
+	if err != nil {
+		log.Printf("Failed to offer waitlist seats : %s\n", err.Error())
+		return
+	}
+	for _, offer := range offers {
+		log.Printf("Waitlist %d offered seats %v for schedule %d\n", offer.ID, offer.Seats, scheduleID)
+	}
+}
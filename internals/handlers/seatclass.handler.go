@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SeatClassHandler struct {
+	Repo *repositories.SeatClassRepo
+}
+
+func NewSeatClassHandler(repo *repositories.SeatClassRepo) *SeatClassHandler {
+	return &SeatClassHandler{Repo: repo}
+}
+
+// GetSeatClasses godoc
+// @Summary Get seat classes
+// @Description Retrieve all seat classes with their fixed price or modifier on top of the schedule base price
+// @Tags Master
+// @Produce json
+// @Success 200 {object} models.ResponseSeatClasses
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/seat-classes [get]
+func (h *SeatClassHandler) GetSeatClasses(ctx *gin.Context) {
+	classes, err := h.Repo.GetAll(ctx.Request.Context())
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.SeatClass]{
+		Success: true,
+		Message: "Success Load Seat Classes",
+		Data:    classes,
+	})
+}
+
+// CreateSeatClass godoc
+// @Summary Create a seat class
+// @Description Add a seat class. Fill price for a fixed price, or leave it empty to charge the schedule base price plus modifier.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param request body models.SeatClassRequest true "Seat class"
+// @Success 201 {object} models.ResponseSeatClass
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Code already used"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/seat-classes [post]
+func (h *SeatClassHandler) CreateSeatClass(ctx *gin.Context) {
+	var req models.SeatClassRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	class, err := h.Repo.Create(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSeatClassExists) {
+			utils.HandleError(ctx, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, models.Response[models.SeatClass]{
+		Success: true,
+		Message: "Success Create Seat Class",
+		Data:    *class,
+	})
+}
+
+// UpdateSeatClass godoc
+// @Summary Update a seat class price
+// @Description Change the name, fixed price or modifier of a seat class. Existing orders keep the price they were sold at.
+// @Tags Master
+// @Accept json
+// @Produce json
+// @Param id path int true "Seat class ID"
+// @Param request body models.SeatClassUpdateRequest true "Seat class"
+// @Success 200 {object} models.ResponseSeatClass
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /master/seat-classes/{id} [put]
+func (h *SeatClassHandler) UpdateSeatClass(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id < 1 {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", "invalid seat class id")
+		return
+	}
+
+	var req models.SeatClassUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	class, err := h.Repo.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSeatClassNotFound) {
+			utils.HandleError(ctx, http.StatusNotFound, "Not Found", err.Error())
+			return
+		}
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.SeatClass]{
+		Success: true,
+		Message: "Success Update Seat Class",
+		Data:    *class,
+	})
+}
@@ -1,11 +1,11 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
 	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
@@ -71,10 +71,17 @@ func (h *UserHandler) GetProfile(ctx *gin.Context) {
 
 // GetHistory godoc
 // @Summary Get order history
-// @Description Mengambil riwayat order user yang sedang login
+// @Description Mengambil riwayat order user yang sedang login per halaman, bisa difilter upcoming / past berdasarkan jam tayang, status dan movie
 // @Tags Users
 // @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Orders per page (default 10, max 50)"
+// @Param when query string false "upcoming or past, based on the showtime"
+// @Param status query string false "Order status"
+// @Param movie_id query int false "Movie ID"
+// @Param sort query string false "newest (default), oldest, showtime_asc or showtime_desc"
 // @Success 200 {object} models.ResponseOrderHistory
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 401 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Security ApiKeyAuth
@@ -86,8 +93,25 @@ func (h *UserHandler) GetHistory(ctx *gin.Context) {
 		return
 	}
 
+	var query models.OrderHistoryQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	if query.Sort == "" {
+		query.Sort = models.HistorySortNewest
+	}
+
+	// setiap kombinasi query punya key sendiri, semuanya dihapus bersama lewat utils.InvalidateUserHistory
 	var cachedData models.OrderHistoryResponse
-	redisKey := fmt.Sprintf("Ntisrangga142-UserHistory-%d", userID)
+	redisKey := fmt.Sprintf("Ntisrangga142-UserHistory-%d-%d-%d-%s-%s-%d-%s",
+		userID, query.Page, query.Limit, query.When, query.Status, query.MovieID, query.Sort)
 	if err := utils.CacheHit(ctx.Request.Context(), h.Rdb, redisKey, &cachedData); err == nil {
 		ctx.JSON(http.StatusOK, models.Response[models.OrderHistoryResponse]{
 			Success: true,
@@ -97,7 +121,7 @@ func (h *UserHandler) GetHistory(ctx *gin.Context) {
 		return
 	}
 
-	history, err := h.repo.GetHistoryByUserID(ctx, userID)
+	history, err := h.repo.GetHistoryByUserID(ctx, userID, query)
 	if err != nil {
 		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
 		return
@@ -114,6 +138,43 @@ func (h *UserHandler) GetHistory(ctx *gin.Context) {
 	})
 }
 
+// GetPoints godoc
+// @Summary Get loyalty points
+// @Description Mengambil saldo poin user yang sedang login beserta ledger perubahan poin terbaru
+// @Tags Users
+// @Produce json
+// @Param limit query int false "Number of ledger entries (default 50, max 200)"
+// @Success 200 {object} models.ResponseUserPoints
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /user/points [get]
+func (h *UserHandler) GetPoints(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		limit = 50
+	}
+	limit = min(limit, 200)
+
+	points, err := h.repo.GetPoints(ctx.Request.Context(), userID, limit)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.UserPoints]{
+		Success: true,
+		Message: "Success Load Points",
+		Data:    points,
+	})
+}
+
 // UpdateProfile godoc
 // @Summary Update user profile
 // @Description Mengupdate profil user termasuk upload profile image
@@ -173,20 +234,79 @@ func (h *UserHandler) UpdateProfile(ctx *gin.Context) {
 	})
 }
 
-func (h *UserHandler) GetVirtualAccountHandler(c *gin.Context) {
-	userID, err := utils.GetUserIDFromJWT(c)
+// GetVirtualAccounts godoc
+// @Summary Get active virtual accounts
+// @Description Mengambil nomor virtual account yang masih menunggu transfer untuk order user, beserta nominal dan batas waktunya
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.ResponseUserVAs
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /user/va [get]
+func (h *UserHandler) GetVirtualAccounts(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	vas, err := h.repo.GetVirtualAccounts(ctx.Request.Context(), userID)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.UserVA]{
+		Success: true,
+		Message: "Success Load Virtual Accounts",
+		Data:    vas,
+	})
+}
+
+// ClaimOrders godoc
+// @Summary Claim guest orders
+// @Description Move orders placed through guest checkout into the logged-in account. Each order needs its booking code and must have been placed with the account's email. Loyalty points are not earned retroactively.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body models.ClaimOrdersRequest true "Booking codes of the guest orders"
+// @Success 200 {object} models.ResponseClaimOrders
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /user/orders/claim [post]
+func (h *UserHandler) ClaimOrders(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
 	if err != nil {
-		utils.HandleError(c, http.StatusUnauthorized, "Unauthorized", err.Error())
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	var req models.ClaimOrdersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
 		return
 	}
 
-	users, err := h.repo.GetVirtualAccountByID(context.Background(), userID)
+	res, err := h.repo.ClaimGuestOrders(ctx.Request.Context(), userID, req.BookingCodes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"success": true, "data": users})
+	if len(res.OrderIDs) > 0 {
+		if err := utils.InvalidateUserOrders(ctx.Request.Context(), h.Rdb, userID); err != nil {
+			log.Println("Failed to invalidate redis cache:", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[models.ClaimOrdersResult]{
+		Success: true,
+		Message: "Success Claim Orders",
+		Data:    res,
+	})
 }
 
 func (h *UserHandler) ChangePassword(ctx *gin.Context) {
@@ -235,3 +355,98 @@ func (h *UserHandler) ChangePassword(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "password changed successfully"})
 }
+
+// GetWaitlist godoc
+// @Summary Get waitlist entries
+// @Description Mengambil antrean waitlist user yang masih menunggu beserta posisinya, dan kursi yang sedang ditawarkan beserta batas waktu checkout
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.ResponseWaitlistEntries
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /user/waitlist [get]
+func (h *UserHandler) GetWaitlist(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	entries, err := h.repo.GetWaitlist(ctx.Request.Context(), userID)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.WaitlistEntry]{
+		Success: true,
+		Message: "Success Load Waitlist",
+		Data:    entries,
+	})
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Mengambil notifikasi terbaru user, misalnya tawaran kursi dari waitlist
+// @Tags Users
+// @Produce json
+// @Param limit query int false "Number of notifications (default 50, max 200)"
+// @Success 200 {object} models.ResponseNotifications
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /user/notifications [get]
+func (h *UserHandler) GetNotifications(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		limit = 50
+	}
+	limit = min(limit, 200)
+
+	notifications, err := h.repo.GetNotifications(ctx.Request.Context(), userID, limit)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[[]models.Notification]{
+		Success: true,
+		Message: "Success Load Notifications",
+		Data:    notifications,
+	})
+}
+
+// ReadNotifications godoc
+// @Summary Mark notifications as read
+// @Description Menandai semua notifikasi user sudah dibaca
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.ResponseMessage
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /user/notifications/read [post]
+func (h *UserHandler) ReadNotifications(ctx *gin.Context) {
+	userID, err := utils.GetUserIDFromJWT(ctx)
+	if err != nil {
+		utils.HandleError(ctx, http.StatusUnauthorized, "Unauthorized", err.Error())
+		return
+	}
+
+	if err := h.repo.ReadNotifications(ctx.Request.Context(), userID); err != nil {
+		utils.HandleError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.Response[any]{
+		Success: true,
+		Message: "Success Read Notifications",
+	})
+}
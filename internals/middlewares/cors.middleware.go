@@ -47,9 +47,9 @@ func CORSMiddleware() gin.HandlerFunc {
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, Idempotency-Key, X-Guest-Token")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Expose-Headers", "Authorization, Content-Type")
+		c.Header("Access-Control-Expose-Headers", "Authorization, Content-Type, Idempotent-Replayed")
 
 		// Preflight request handling
 		if c.Request.Method == http.MethodOptions {
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const guestTokenHeader = "X-Guest-Token"
+
+// token dibuat acak oleh client dan dipakai ulang selama satu sesi checkout tamu
+var guestTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{16,64}$`)
+
+// GuestSession mewajibkan header X-Guest-Token untuk checkout tanpa login,
+// token menjadi pemilik hold kursi sehingga hanya sesi yang sama yang bisa checkout kursi tersebut
+func GuestSession(ctx *gin.Context) {
+	token := ctx.GetHeader(guestTokenHeader)
+	if token == "" {
+		utils.HandleMiddlewareError(ctx, http.StatusUnauthorized, "Unauthorized Access", "X-Guest-Token header is missing")
+		return
+	}
+	if !guestTokenPattern.MatchString(token) {
+		utils.HandleMiddlewareError(ctx, http.StatusBadRequest, "Bad Request", "X-Guest-Token must be 16-64 characters of letters, digits, '-' or '_'")
+		return
+	}
+
+	ctx.Set("guest_token", token)
+	ctx.Next()
+}
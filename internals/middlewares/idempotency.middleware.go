@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/configs"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/Ntisrangga142/API_tickytiz/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	idempotencyHeader       = "Idempotency-Key"
+	idempotencyReplayHeader = "Idempotent-Replayed"
+	idempotencyMaxKeyLength = 255
+)
+
+// idempotencyRecord disimpan di redis per key, Status kosong berarti request pertama masih diproses
+type idempotencyRecord struct {
+	BodyHash    string `json:"body_hash"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// idempotencyWriter menyalin response supaya bisa disimpan dan diputar ulang
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencyScope memisahkan key antar user dan sesi tamu, request tanpa keduanya memakai scope guest
+func idempotencyScope(ctx *gin.Context) string {
+	if claims, ok := ctx.Get("claims"); ok {
+		if user, ok := claims.(pkg.Claims); ok {
+			return fmt.Sprintf("user-%d", user.UserId)
+		}
+	}
+	if token := ctx.GetString("guest_token"); token != "" {
+		return fmt.Sprintf("guest-%s", token)
+	}
+	return "guest"
+}
+
+// Idempotency memutar ulang response pertama untuk request dengan Idempotency-Key dan body yang sama.
+// Key yang sama dengan body berbeda ditolak, dan key yang masih diproses mengembalikan 409.
+// Response 5xx, handler yang panic dan response yang gagal disimpan melepas key supaya client bisa mencoba lagi dengan key yang sama.
+func Idempotency(ctx *gin.Context) {
+	key := ctx.GetHeader(idempotencyHeader)
+	if key == "" {
+		ctx.Next()
+		return
+	}
+	if len(key) > idempotencyMaxKeyLength {
+		utils.HandleMiddlewareError(ctx, http.StatusBadRequest, "Bad Request", fmt.Sprintf("%s must be at most %d characters", idempotencyHeader, idempotencyMaxKeyLength))
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		utils.HandleMiddlewareError(ctx, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+	record := idempotencyRecord{BodyHash: hex.EncodeToString(sum[:])}
+	redisKey := fmt.Sprintf("Ntisrangga142-Idempotency-%s-%s %s-%s", idempotencyScope(ctx), ctx.Request.Method, ctx.FullPath(), key)
+	rctx := ctx.Request.Context()
+	ttl := configs.IdempotencyTTL()
+
+	bt, err := json.Marshal(record)
+	if err != nil {
+		utils.HandleMiddlewareError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+	acquired, err := RDB.SetNX(rctx, redisKey, bt, ttl).Result()
+	if err != nil {
+		utils.HandleMiddlewareError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	if !acquired {
+		var stored idempotencyRecord
+		if err := utils.CacheHit(rctx, RDB, redisKey, &stored); err != nil {
+			if errors.Is(err, redis.Nil) {
+				utils.HandleMiddlewareError(ctx, http.StatusConflict, "Conflict", "request with this Idempotency-Key has just expired, please retry")
+				return
+			}
+			utils.HandleMiddlewareError(ctx, http.StatusInternalServerError, "Internal Server Error", err.Error())
+			return
+		}
+
+		switch {
+		case stored.BodyHash != record.BodyHash:
+			utils.HandleMiddlewareError(ctx, http.StatusUnprocessableEntity, "Unprocessable Entity", "Idempotency-Key was already used with a different request body")
+		case stored.Status == 0:
+			utils.HandleMiddlewareError(ctx, http.StatusConflict, "Conflict", "request with this Idempotency-Key is still being processed")
+		default:
+			ctx.Header(idempotencyReplayHeader, "true")
+			ctx.Data(stored.Status, stored.ContentType, stored.Body)
+			ctx.Abort()
+		}
+		return
+	}
+
+	// placeholder dilepas lewat defer supaya handler yang panic tidak mengunci key sampai ttl habis
+	stored := false
+	defer func() {
+		if stored {
+			return
+		}
+		if err := utils.InvalidateCache(rctx, RDB, redisKey); err != nil {
+			log.Printf("Failed to release idempotency key : %s\n", err.Error())
+		}
+	}()
+
+	writer := &idempotencyWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = writer
+	ctx.Next()
+
+	status := writer.Status()
+	if status >= http.StatusInternalServerError {
+		return
+	}
+
+	record.Status = status
+	record.ContentType = writer.Header().Get("Content-Type")
+	record.Body = writer.body.Bytes()
+	bt, err = json.Marshal(record)
+	if err == nil {
+		err = RDB.Set(rctx, redisKey, bt, ttl).Err()
+	}
+	if err != nil {
+		log.Printf("Failed to store idempotent response : %s\n", err.Error())
+		return
+	}
+	stored = true
+}
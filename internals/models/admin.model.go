@@ -73,11 +73,13 @@ type MovieAdminInsert struct {
 	UpdatedAt   time.Time
 }
 
+// ScheduleComboAdminInsert id_auditorium kosong berarti studio pertama milik cinema
 type ScheduleComboAdminInsert struct {
-	Date       string `json:"date"`
-	IdCinema   int    `json:"id_cinema"`
-	IdLocation int    `json:"id_location"`
-	IdTime     int    `json:"id_time"`
+	Date         string `json:"date"`
+	IdCinema     int    `json:"id_cinema"`
+	IdLocation   int    `json:"id_location"`
+	IdTime       int    `json:"id_time"`
+	IdAuditorium int    `json:"id_auditorium"`
 }
 
 type GetMovieDetailUpdate struct {
@@ -101,14 +103,16 @@ type Actor struct {
 }
 
 type ScheduleDetail struct {
-	ID         int    `json:"id"`
-	Date       string `json:"date"`
-	CinemaID   int    `json:"cinema_id"`
-	Cinema     string `json:"cinema"`
-	LocationID int    `json:"location_id"`
-	Location   string `json:"location"`
-	TimeID     int    `json:"time_id"`
-	Time       string `json:"time"`
+	ID           int    `json:"id"`
+	Date         string `json:"date"`
+	CinemaID     int    `json:"cinema_id"`
+	Cinema       string `json:"cinema"`
+	AuditoriumID int    `json:"auditorium_id"`
+	Auditorium   string `json:"auditorium"`
+	LocationID   int    `json:"location_id"`
+	Location     string `json:"location"`
+	TimeID       int    `json:"time_id"`
+	Time         string `json:"time"`
 }
 
 type MovieUpdateAdmin struct {
@@ -123,10 +127,11 @@ type MovieUpdateAdmin struct {
 	Schedules    []ScheduleUpdate
 }
 
-// ScheduleUpdate untuk update schedule
+// ScheduleUpdate untuk update schedule, id_auditorium kosong berarti studio pertama milik cinema
 type ScheduleUpdate struct {
-	Date       string `json:"date"`
-	CinemaID   int    `json:"id_cinema"`
-	LocationID int    `json:"id_location"`
-	TimeID     int    `json:"id_time"`
+	Date         string `json:"date"`
+	CinemaID     int    `json:"id_cinema"`
+	LocationID   int    `json:"id_location"`
+	TimeID       int    `json:"id_time"`
+	AuditoriumID int    `json:"id_auditorium"`
 }
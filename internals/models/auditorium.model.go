@@ -0,0 +1,100 @@
+package models
+
+// Status kursi pada seat map schedule
+const (
+	SeatStatusAvailable = "available"
+	SeatStatusHeld      = "held"
+	SeatStatusSold      = "sold"
+	SeatStatusDisabled  = "disabled"
+)
+
+// SeatLayout denah studio. Baris diberi label A, B, C, ... dan kolom dinomori dari 1,
+// sehingga id kursi berbentuk <baris><kolom> seperti A1.
+// Wheelchair ruang kursi roda, Companion kursi pendamping yang harus bersebelahan dengan ruang kursi roda.
+type SeatLayout struct {
+	Rows       int             `json:"rows" binding:"required,min=1,max=26" example:"8"`
+	Columns    int             `json:"columns" binding:"required,min=1,max=50" example:"14"`
+	Aisles     []int           `json:"aisles" binding:"omitempty,unique,dive,min=1" example:"7"`
+	Gaps       []string        `json:"gaps" binding:"omitempty,unique,dive,required" example:"A1"`
+	Disabled   []string        `json:"disabled" binding:"omitempty,unique,dive,required" example:"H14"`
+	Wheelchair []string        `json:"wheelchair" binding:"omitempty,unique,dive,required" example:"H1"`
+	Companion  []string        `json:"companion" binding:"omitempty,unique,dive,required" example:"H2"`
+	Classes    []SeatClassArea `json:"classes" binding:"omitempty,dive"`
+}
+
+// SeatClassArea memberi kelas ke seluruh baris dan / atau kursi tertentu, kursi di luar area memakai kelas regular.
+// Area yang ditulis belakangan menimpa area sebelumnya.
+type SeatClassArea struct {
+	Class string   `json:"class" binding:"required" example:"premium"`
+	Rows  []string `json:"rows" example:"G,H"`
+	Seats []string `json:"seats" example:"F7,F8"`
+}
+
+type Auditorium struct {
+	ID       int        `json:"id" example:"1"`
+	CinemaID int        `json:"cinema_id" example:"1"`
+	Name     string     `json:"name" example:"Studio 1"`
+	Capacity int        `json:"capacity" example:"112"`
+	Layout   SeatLayout `json:"layout"`
+}
+
+type AuditoriumRequest struct {
+	Name   string     `json:"name" binding:"required" example:"Studio 1"`
+	Layout SeatLayout `json:"layout" binding:"required"`
+}
+
+// AuditoriumSeat satu kursi hasil denah, kursi gap tidak pernah dibuat
+type AuditoriumSeat struct {
+	ID          string  `json:"id" example:"A1"`
+	Row         string  `json:"row" example:"A"`
+	Column      int     `json:"column" example:"1"`
+	Disabled    bool    `json:"disabled" example:"false"`
+	SeatClassID int     `json:"-"`
+	Class       string  `json:"class" example:"couple"`
+	Pair        *string `json:"pair" example:"H2"`
+	Access      *string `json:"access" example:"wheelchair"`
+}
+
+// Atribut aksesibilitas kursi
+const (
+	SeatAccessWheelchair = "wheelchair"
+	SeatAccessCompanion  = "companion"
+)
+
+type SeatMapSeat struct {
+	ID     string  `json:"id" example:"A1"`
+	Row    string  `json:"row" example:"A"`
+	Column int     `json:"column" example:"1"`
+	Class  string  `json:"class" example:"regular"`
+	Price  int     `json:"price" example:"50"`
+	Pair   *string `json:"pair,omitempty" example:"H2"`
+	Access *string `json:"access,omitempty" example:"wheelchair"`
+	Status string  `json:"status" example:"available"`
+}
+
+const SeatClassRegular = "regular"
+
+// SeatClass harga kelas kursi. Price diisi berarti harga tetap, kosong berarti harga dasar schedule (pricing rule) + Modifier.
+// PairsOnly (couple sofa) hanya bisa dibeli berpasangan.
+type SeatClass struct {
+	ID        int    `json:"id" example:"3"`
+	Code      string `json:"code" example:"couple"`
+	Name      string `json:"name" example:"Couple Sofa"`
+	Price     *int   `json:"price" example:"80"`
+	Modifier  int    `json:"modifier" example:"20"`
+	PairsOnly bool   `json:"pairs_only" example:"true"`
+}
+
+type SeatClassRequest struct {
+	Code      string `json:"code" binding:"required,max=20" example:"couple"`
+	Name      string `json:"name" binding:"required" example:"Couple Sofa"`
+	Price     *int   `json:"price" binding:"omitempty,min=0" example:"80"`
+	Modifier  int    `json:"modifier" example:"20"`
+	PairsOnly bool   `json:"pairs_only" example:"true"`
+}
+
+type SeatClassUpdateRequest struct {
+	Name     string `json:"name" binding:"required" example:"Couple Sofa"`
+	Price    *int   `json:"price" binding:"omitempty,min=0" example:"80"`
+	Modifier int    `json:"modifier" example:"20"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// CheckinRequest code bisa berupa isi QR code (TKT.xxx) atau booking code yang diketik manual
+type CheckinRequest struct {
+	Code string `json:"code" binding:"required" example:"K7QM2XR9TB"`
+}
+
+type CheckinResponse struct {
+	OrderID     int       `json:"order_id" example:"101"`
+	BookingCode string    `json:"booking_code" example:"K7QM2XR9TB"`
+	Name        string    `json:"name" example:"John Doe"`
+	Title       string    `json:"title" example:"Inception"`
+	Cinema      string    `json:"cinema" example:"Cineworld"`
+	Location    string    `json:"location" example:"Jakarta"`
+	Date        time.Time `json:"date" example:"2025-09-20T00:00:00Z"`
+	Time        string    `json:"time" example:"19:30:00"`
+	Seat        []string  `json:"seat" example:"A1,A2"`
+	CheckinAt   time.Time `json:"checkin_at" example:"2025-09-20T19:05:00Z"`
+}
+
+// TicketUsed data yang dikirim ketika tiket sudah pernah di-scan
+type TicketUsed struct {
+	OrderID     int       `json:"order_id" example:"101"`
+	BookingCode string    `json:"booking_code" example:"K7QM2XR9TB"`
+	CheckinAt   time.Time `json:"checkin_at" example:"2025-09-20T19:05:00Z"`
+}
@@ -50,6 +50,18 @@ type ResponseOrders struct {
 	Data    OrderResponse `json:"data"`
 }
 
+type ResponseOrderStatus struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Success Load Order"`
+	Data    OrderStatus `json:"data"`
+}
+
+type ResponseOrderQuote struct {
+	Success bool       `json:"success" example:"true"`
+	Message string     `json:"message" example:"Success Quote Order"`
+	Data    OrderQuote `json:"data"`
+}
+
 type ResponseSchedule struct {
 	Success bool             `json:"success" example:"true"`
 	Message string           `json:"message" example:"Success Load Movies"`
@@ -85,3 +97,183 @@ type ResponseCreateMovie struct {
 	Message string                   `json:"message" example:"Success Load Movies"`
 	Data    AdminInsertMovieResponse `json:"data"`
 }
+
+type ResponseSeatHold struct {
+	Success bool             `json:"success" example:"true"`
+	Message string           `json:"message" example:"Success Hold Seats"`
+	Data    SeatHoldResponse `json:"data"`
+}
+
+type ResponseSeatRelease struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message" example:"Success Release Seats"`
+	Data    SeatReleaseResponse `json:"data"`
+}
+
+type ResponseOrderDetail struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Success Load Order"`
+	Data    OrderDetail `json:"data"`
+}
+
+type ResponseOrderLookup struct {
+	Success bool         `json:"success" example:"true"`
+	Message string       `json:"message" example:"Success Load Order"`
+	Data    OrderHistory `json:"data"`
+}
+
+type ResponsePayment struct {
+	Success bool    `json:"success" example:"true"`
+	Message string  `json:"message" example:"Success Create Payment"`
+	Data    Payment `json:"data"`
+}
+
+type ResponseCheckin struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success Check In"`
+	Data    CheckinResponse `json:"data"`
+}
+
+type ResponseOrderCancellation struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Success Cancel Order"`
+	Data    OrderCancellation `json:"data"`
+}
+
+type ResponseAuditorium struct {
+	Success bool       `json:"success" example:"true"`
+	Message string     `json:"message" example:"Success Load Auditorium"`
+	Data    Auditorium `json:"data"`
+}
+
+type ResponseAuditoriums struct {
+	Success bool         `json:"success" example:"true"`
+	Message string       `json:"message" example:"Success Load Auditoriums"`
+	Data    []Auditorium `json:"data"`
+}
+
+type ResponseSeatClass struct {
+	Success bool      `json:"success" example:"true"`
+	Message string    `json:"message" example:"Success Load Seat Class"`
+	Data    SeatClass `json:"data"`
+}
+
+type ResponseSeatClasses struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Success Load Seat Classes"`
+	Data    []SeatClass `json:"data"`
+}
+
+type ResponsePricingRule struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Success Load Pricing Rule"`
+	Data    PricingRule `json:"data"`
+}
+
+type ResponsePricingRules struct {
+	Success bool          `json:"success" example:"true"`
+	Message string        `json:"message" example:"Success Load Pricing Rules"`
+	Data    []PricingRule `json:"data"`
+}
+
+type ResponseHoliday struct {
+	Success bool    `json:"success" example:"true"`
+	Message string  `json:"message" example:"Success Load Holiday"`
+	Data    Holiday `json:"data"`
+}
+
+type ResponseHolidays struct {
+	Success bool      `json:"success" example:"true"`
+	Message string    `json:"message" example:"Success Load Holidays"`
+	Data    []Holiday `json:"data"`
+}
+
+type ResponsePricingPreview struct {
+	Success bool           `json:"success" example:"true"`
+	Message string         `json:"message" example:"Success Preview Pricing"`
+	Data    PricingPreview `json:"data"`
+}
+
+type ResponsePromoCode struct {
+	Success bool      `json:"success" example:"true"`
+	Message string    `json:"message" example:"Success Load Promo Code"`
+	Data    PromoCode `json:"data"`
+}
+
+type ResponsePromoCodes struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Success Load Promo Codes"`
+	Data    []PromoCode `json:"data"`
+}
+
+type ResponseClaimOrders struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message" example:"Success Claim Orders"`
+	Data    ClaimOrdersResult `json:"data"`
+}
+
+type ResponseUserVAs struct {
+	Success bool     `json:"success" example:"true"`
+	Message string   `json:"message" example:"Success Load Virtual Accounts"`
+	Data    []UserVA `json:"data"`
+}
+
+type ResponseUserPoints struct {
+	Success bool       `json:"success" example:"true"`
+	Message string     `json:"message" example:"Success Load Points"`
+	Data    UserPoints `json:"data"`
+}
+
+type ResponseCinemaSeatRules struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success Update Seat Rules"`
+	Data    CinemaSeatRules `json:"data"`
+}
+
+type ResponseSeatChange struct {
+	Success bool       `json:"success" example:"true"`
+	Message string     `json:"message" example:"Success Change Seats"`
+	Data    SeatChange `json:"data"`
+}
+
+type ResponseOrderTransfer struct {
+	Success bool          `json:"success" example:"true"`
+	Message string        `json:"message" example:"Success Transfer Order"`
+	Data    OrderTransfer `json:"data"`
+}
+
+type ResponseOrderTransfers struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success Load Transfers"`
+	Data    []OrderTransfer `json:"data"`
+}
+
+type ResponseOrderConflicts struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success Load Order Conflicts"`
+	Data    []OrderConflict `json:"data"`
+}
+
+type ResponseWaitlistEntry struct {
+	Success bool          `json:"success" example:"true"`
+	Message string        `json:"message" example:"Success Join Waitlist"`
+	Data    WaitlistEntry `json:"data"`
+}
+
+type ResponseWaitlistEntries struct {
+	Success bool            `json:"success" example:"true"`
+	Message string          `json:"message" example:"Success Load Waitlist"`
+	Data    []WaitlistEntry `json:"data"`
+}
+
+type ResponseNotifications struct {
+	Success bool           `json:"success" example:"true"`
+	Message string         `json:"message" example:"Success Load Notifications"`
+	Data    []Notification `json:"data"`
+}
+
+type ResponseMessage struct {
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message" example:"Success Delete"`
+	Data    any    `json:"data"`
+}
@@ -5,6 +5,7 @@ type ErrorResponse struct {
 	Status  string `json:"status" example:"HTTP Status Error"`
 	Code    int    `json:"status_code" example:"400"`
 	Error   string `json:"error"  example:"Error Message"`
+	Data    any    `json:"data,omitempty"`
 }
 
 func NewErrorResponse(status, err string, code int) ErrorResponse {
@@ -15,3 +16,9 @@ func NewErrorResponse(status, err string, code int) ErrorResponse {
 		Error:   err,
 	}
 }
+
+func NewErrorDataResponse(status, err string, code int, data any) ErrorResponse {
+	res := NewErrorResponse(status, err, code)
+	res.Data = data
+	return res
+}
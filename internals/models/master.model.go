@@ -21,8 +21,19 @@ type MasterTime struct {
 }
 
 type MasterCinema struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Logo  string `json:"logo"`
-	Price int    `json:"price"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Logo         string `json:"logo"`
+	Price        int    `json:"price"`
+	NoOrphanSeat bool   `json:"no_orphan_seat"`
+}
+
+// CinemaSeatRulesRequest aturan pemilihan kursi per bioskop
+type CinemaSeatRulesRequest struct {
+	NoOrphanSeat *bool `json:"no_orphan_seat" binding:"required" example:"true"`
+}
+
+type CinemaSeatRules struct {
+	CinemaID     int  `json:"cinema_id" example:"1"`
+	NoOrphanSeat bool `json:"no_orphan_seat" example:"true"`
 }
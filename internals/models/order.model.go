@@ -1,22 +1,321 @@
 package models
 
+import "time"
+
+// Status order, perpindahan antar status dijaga di repository
+const (
+	OrderStatusPending         = "pending"
+	OrderStatusAwaitingPayment = "awaiting_payment"
+	OrderStatusPaid            = "paid"
+	OrderStatusExpired         = "expired"
+	OrderStatusCancelled       = "cancelled"
+	OrderStatusRefunded        = "refunded"
+)
+
+// OrderRequest tidak menerima total harga, status bayar, maupun qrcode dari client, semuanya ditentukan server
 type OrderRequest struct {
-	IsPaid          bool     `json:"is_paid"`
-	TotalPrice      float64  `json:"total_price" binding:"required"`
-	QRCode          string   `json:"qrcode" binding:"required"`
 	Name            string   `json:"name" binding:"required"`
 	Email           string   `json:"email" binding:"required,email"`
 	Phone           string   `json:"phone" binding:"required"`
 	ScheduleID      int      `json:"id_schedule" binding:"required"`
 	PaymentMethodID int      `json:"id_paymentmethod" binding:"required"`
-	Seat            []string `json:"seat"`
+	Seat            []string `json:"seat" binding:"required,min=1,unique,dive,required"`
+	PromoCode       string   `json:"promo_code" example:"NONTONHEMAT"`
+	Points          int      `json:"points" binding:"min=0" example:"50"`
+	Accessible      bool     `json:"accessible" example:"false"`
+}
+
+// OrderLookupRequest email pemesan dan booking code untuk mencari order tanpa login
+type OrderLookupRequest struct {
+	Email       string `json:"email" binding:"required,email" example:"rangga@example.com"`
+	BookingCode string `json:"booking_code" binding:"required" example:"K7QM2XR9TB"`
 }
 
 type OrderResponse struct {
-	ID     int      `json:"id" example:"101"`
-	Name   string   `json:"name" example:"Rangga Saputra"`
-	Email  string   `json:"email" example:"rangga@example.com"`
-	Phone  string   `json:"phone" example:"+628123456789"`
-	QRCode string   `json:"qrcode" example:"https://example.com/qrcode/101.png"`
-	Seat   []string `json:"seat" example:"A1,A2,A3"`
+	ID          int              `json:"id" example:"101"`
+	Name        string           `json:"name" example:"Rangga Saputra"`
+	Email       string           `json:"email" example:"rangga@example.com"`
+	Phone       string           `json:"phone" example:"+628123456789"`
+	BookingCode string           `json:"booking_code" example:"K7QM2XR9TB"`
+	QRCode      string           `json:"qrcode" example:"TKT.101.12.K7QM2XR9TB.3q2-7wX9..."`
+	Seat        []string         `json:"seat" example:"A1,A2,A3"`
+	Subtotal    int              `json:"subtotal" example:"120"`
+	Discount    int              `json:"discount" example:"20"`
+	TotalPrice  int              `json:"total_price" example:"100"`
+	Items       []OrderQuoteItem `json:"items"`
+	Classes     []OrderClassItem `json:"classes"`
+	Promo       *OrderPromo      `json:"promo,omitempty"`
+	PointsUsed  int              `json:"points_used" example:"0"`
+	Accessible  bool             `json:"accessible" example:"false"`
+	Status      string           `json:"status" example:"pending"`
+	ExpiresAt   time.Time        `json:"expires_at" example:"2025-09-20T19:45:00Z"`
+}
+
+// OrderStatusRequest status tujuan untuk admin. Pembatalan dan refund lewat endpoint cancel supaya kursi, promo dan refund ikut diproses.
+type OrderStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=awaiting_payment paid expired" example:"paid"`
+}
+
+// OrderStatus ringkasan status pembayaran order beserta waktu setiap perpindahan status
+type OrderStatus struct {
+	ID                int        `json:"id" example:"101"`
+	UserID            *int       `json:"user_id" example:"5"`
+	ScheduleID        int        `json:"schedule_id" example:"12"`
+	Status            string     `json:"status" example:"paid"`
+	TotalPrice        int        `json:"total_price" example:"100"`
+	BookingCode       string     `json:"booking_code" example:"K7QM2XR9TB"`
+	CreatedAt         time.Time  `json:"created_at" example:"2025-09-20T19:30:00Z"`
+	ExpiresAt         *time.Time `json:"expires_at" example:"2025-09-20T19:45:00Z"`
+	AwaitingPaymentAt *time.Time `json:"awaiting_payment_at"`
+	PaidAt            *time.Time `json:"paid_at" example:"2025-09-20T19:35:00Z"`
+	ExpiredAt         *time.Time `json:"expired_at"`
+	CancelledAt       *time.Time `json:"cancelled_at"`
+	RefundedAt        *time.Time `json:"refunded_at"`
+	Seat              []string   `json:"seat" example:"A1,A2"`
+}
+
+// OrderDetail detail satu order: film, jadwal, kursi, rincian harga, pembayaran terakhir dan tiket
+type OrderDetail struct {
+	ID                int              `json:"id" example:"101"`
+	UserID            *int             `json:"user_id" example:"5"`
+	Status            string           `json:"status" example:"paid"`
+	IsPaid            bool             `json:"ispaid" example:"true"`
+	BookingCode       string           `json:"booking_code" example:"K7QM2XR9TB"`
+	QRCode            string           `json:"qrcode" example:"TKT.101.12.K7QM2XR9TB.3q2-7wX9..."`
+	Name              string           `json:"name" example:"Rangga Saputra"`
+	Email             string           `json:"email" example:"rangga@example.com"`
+	Phone             string           `json:"phone" example:"+628123456789"`
+	MovieID           int              `json:"movie_id" example:"3"`
+	MovieTitle        string           `json:"movie_title" example:"Avengers: Endgame"`
+	MoviePoster       string           `json:"movie_poster" example:"avengers.jpg"`
+	Duration          int              `json:"duration" example:"180"`
+	Rating            float32          `json:"rating" example:"8.5"`
+	ScheduleID        int              `json:"schedule_id" example:"12"`
+	ShowDate          time.Time        `json:"show_date" example:"2025-09-20T00:00:00Z"`
+	ShowTime          string           `json:"show_time" example:"19:30"`
+	CinemaID          int              `json:"cinema_id" example:"1"`
+	CinemaName        string           `json:"cinema_name" example:"XXI Plaza Indonesia"`
+	CinemaLogo        string           `json:"cinema_logo" example:"XXI.jpg"`
+	LocationName      string           `json:"location_name" example:"Jakarta"`
+	Auditorium        string           `json:"auditorium" example:"Studio 1"`
+	Seats             []string         `json:"seats" example:"A1,A2"`
+	Accessible        bool             `json:"accessible" example:"false"`
+	Items             []OrderClassItem `json:"items"`
+	Subtotal          int              `json:"subtotal" example:"120"`
+	Discount          int              `json:"discount" example:"20"`
+	Promo             *OrderPromo      `json:"promo"`
+	PointsUsed        int              `json:"points_used" example:"0"`
+	TotalPrice        int              `json:"total_price" example:"100"`
+	PaymentMethod     string           `json:"payment_method" example:"BCA"`
+	Payment           *Payment         `json:"payment"`
+	CreatedAt         time.Time        `json:"created_at" example:"2025-09-20T19:30:00Z"`
+	ExpiresAt         *time.Time       `json:"expires_at" example:"2025-09-20T19:45:00Z"`
+	AwaitingPaymentAt *time.Time       `json:"awaiting_payment_at"`
+	PaidAt            *time.Time       `json:"paid_at" example:"2025-09-20T19:35:00Z"`
+	ExpiredAt         *time.Time       `json:"expired_at"`
+	CancelledAt       *time.Time       `json:"cancelled_at"`
+	RefundedAt        *time.Time       `json:"refunded_at"`
+}
+
+// SeatChangeRequest kursi baru pengganti seluruh kursi order, jumlahnya harus sama dengan kursi yang dipesan.
+// Kursi yang tetap dipakai cukup ditulis ulang.
+type SeatChangeRequest struct {
+	Seat []string `json:"seat" binding:"required,min=1,unique,dive,required" example:"C5,C6"`
+}
+
+const (
+	SeatChangeStatusPending = "pending"
+	SeatChangeStatusApplied = "applied"
+	SeatChangeStatusExpired = "expired"
+	SeatChangeStatusFailed  = "failed"
+)
+
+// SeatChange hasil tukar kursi. PriceDifference selisih harga kelas kursi: untuk order yang sudah lunas nilai positif
+// ditagihkan lewat Payment dan nilai negatif dikembalikan lewat Refund, order yang belum lunas cukup membayar TotalPrice yang baru.
+// Selama ChangeStatus pending kursi baru ditahan dan order belum berubah sampai Payment dibayar.
+type SeatChange struct {
+	ID              int        `json:"id" example:"4"`
+	OrderID         int        `json:"order_id" example:"101"`
+	FromSeats       []string   `json:"from_seats" example:"A1"`
+	ToSeats         []string   `json:"to_seats" example:"C5"`
+	Seat            []string   `json:"seat" example:"A2,C5"`
+	PriceDifference int        `json:"price_difference" example:"20"`
+	TotalPrice      int        `json:"total_price" example:"120"`
+	Status          string     `json:"status" example:"paid"`
+	ChangeStatus    string     `json:"change_status" example:"applied"`
+	Payment         *Payment   `json:"payment,omitempty"`
+	Refund          *Refund    `json:"refund,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty" example:"2025-09-20T19:45:00Z"`
+	CreatedAt       time.Time  `json:"created_at" example:"2025-09-20T19:30:00Z"`
+}
+
+// OrderCancelRequest alasan pembatalan opsional, dicatat di data refund
+type OrderCancelRequest struct {
+	Reason string `json:"reason" example:"Screening cancelled"`
+}
+
+type Refund struct {
+	ID               int       `json:"id" example:"7"`
+	OrderID          int       `json:"order_id" example:"101"`
+	PaymentID        *int      `json:"payment_id" example:"33"`
+	Provider         *string   `json:"provider" example:"mock"`
+	PaymentReference *string   `json:"payment_reference" example:"MOCK-1a2b3c4d5e6f7a8b"`
+	Reference        *string   `json:"reference" example:"MOCKRF-1a2b3c4d5e6f7a8b"`
+	Amount           int       `json:"amount" example:"100"`
+	Status           string    `json:"status" example:"refunded"`
+	Reason           *string   `json:"reason" example:"Screening cancelled"`
+	CreatedAt        time.Time `json:"created_at" example:"2025-09-20T19:30:00Z"`
+}
+
+// OrderCancellation hasil pembatalan order, refund hanya ada untuk order yang sudah dibayar
+type OrderCancellation struct {
+	Order  OrderStatus `json:"order"`
+	Refund *Refund     `json:"refund,omitempty"`
+}
+
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+)
+
+// OrderTransferRequest email akun penerima tiket
+type OrderTransferRequest struct {
+	Email string `json:"email" binding:"required,email" example:"budi@example.com"`
+}
+
+// OrderTransfer permintaan pindah tangan tiket. BookingCode hanya diisi untuk penerima setelah transfer diterima.
+type OrderTransfer struct {
+	ID          int        `json:"id" example:"3"`
+	OrderID     int        `json:"order_id" example:"101"`
+	FromUserID  int        `json:"from_user_id" example:"12"`
+	FromEmail   string     `json:"from_email" example:"rangga@example.com"`
+	ToUserID    int        `json:"to_user_id" example:"15"`
+	ToEmail     string     `json:"to_email" example:"budi@example.com"`
+	Status      string     `json:"status" example:"pending"`
+	MovieTitle  string     `json:"movie_title" example:"Avengers: Endgame"`
+	CinemaName  string     `json:"cinema_name" example:"XXI Plaza Indonesia"`
+	ShowDate    time.Time  `json:"show_date" example:"2025-09-20T00:00:00Z"`
+	ShowTime    string     `json:"show_time" example:"19:30"`
+	Seat        []string   `json:"seat" example:"A1,A2"`
+	BookingCode *string    `json:"booking_code,omitempty" example:"P4ZN8WQ2HC"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-09-20T10:00:00Z"`
+	RespondedAt *time.Time `json:"responded_at" example:"2025-09-20T10:05:00Z"`
+}
+
+type OrderTransitionConflict struct {
+	From    string   `json:"from" example:"expired"`
+	To      string   `json:"to" example:"paid"`
+	Allowed []string `json:"allowed"`
+}
+
+// OrderQuoteRequest PaymentMethodID opsional, jika kosong pembatasan payment method promo baru dicek saat order dibuat.
+// Saldo poin tidak dicek saat quote, hanya saat order dibuat.
+type OrderQuoteRequest struct {
+	ScheduleID      int      `json:"id_schedule" binding:"required"`
+	Seat            []string `json:"seat" binding:"required,min=1,unique,dive,required"`
+	PaymentMethodID int      `json:"id_paymentmethod" example:"3"`
+	PromoCode       string   `json:"promo_code" example:"NONTONHEMAT"`
+	Points          int      `json:"points" binding:"min=0" example:"50"`
+}
+
+type OrderQuoteItem struct {
+	Seat        string `json:"seat" example:"A1"`
+	Class       string `json:"class" example:"regular"`
+	SeatClassID int    `json:"-"`
+	Price       int    `json:"price" example:"50"`
+}
+
+// OrderClassItem rincian kursi per kelas untuk quote dan struk
+type OrderClassItem struct {
+	Class     string   `json:"class" example:"premium"`
+	ClassName string   `json:"class_name" example:"Premium"`
+	Seats     []string `json:"seats" example:"G1,G2"`
+	Quantity  int      `json:"quantity" example:"2"`
+	UnitPrice int      `json:"unit_price" example:"60"`
+	Subtotal  int      `json:"subtotal" example:"120"`
+}
+
+// OrderQuote UnitPrice adalah harga dasar schedule, harga setiap kursi mengikuti kelasnya
+type OrderQuote struct {
+	ScheduleID     int              `json:"schedule_id" example:"12"`
+	UnitPrice      int              `json:"unit_price" example:"50"`
+	Items          []OrderQuoteItem `json:"items"`
+	Classes        []OrderClassItem `json:"classes"`
+	Subtotal       int              `json:"subtotal" example:"120"`
+	Discount       int              `json:"discount" example:"20"`
+	Promo          *OrderPromo      `json:"promo,omitempty"`
+	PointsUsed     int              `json:"points_used" example:"0"`
+	PointsDiscount int              `json:"points_discount" example:"0"`
+	Total          int              `json:"total" example:"100"`
+}
+
+// PointsRejection saldo poin saat poin yang ditukar melebihi saldo
+type PointsRejection struct {
+	Balance   int `json:"balance" example:"30"`
+	Requested int `json:"requested" example:"50"`
+}
+
+type SeatConflict struct {
+	ScheduleID int      `json:"schedule_id" example:"12"`
+	Seats      []string `json:"seat" example:"A1,A2"`
+}
+
+// Cakupan batas kursi pada SeatLimitExceeded
+const (
+	SeatLimitScopeOrder    = "order"
+	SeatLimitScopeSchedule = "schedule"
+)
+
+// SeatLimitExceeded batas kursi yang terlampaui. Used kursi yang sudah di-hold / dimiliki sebelum request ini,
+// Requested kursi baru pada request ini.
+type SeatLimitExceeded struct {
+	Scope      string `json:"scope" example:"schedule"`
+	ScheduleID int    `json:"schedule_id" example:"12"`
+	Limit      int    `json:"limit" example:"10"`
+	Used       int    `json:"used" example:"8"`
+	Requested  int    `json:"requested" example:"4"`
+}
+
+// Alasan penolakan pada SeatRuleViolation
+const (
+	SeatRuleOrphanSeat = "orphan_seat"
+)
+
+// SeatRuleViolation seleksi kursi yang melanggar aturan bioskop. OrphanSeats kursi kosong tunggal yang akan tercipta,
+// Suggestions alternatif kursi berjumlah sama yang tidak meninggalkan kursi kosong tunggal, urut dari yang terdekat.
+type SeatRuleViolation struct {
+	Reason      string     `json:"reason" example:"orphan_seat"`
+	ScheduleID  int        `json:"schedule_id" example:"12"`
+	OrphanSeats []string   `json:"orphan_seat" example:"A3"`
+	Suggestions [][]string `json:"suggestions"`
+}
+
+// OrderConflict kursi yang terjual ganda sebelum unique (id_schedule, id_seat) dipasang dan dilepas dari order oleh migrasi.
+// KeptOrderID order yang mempertahankan kursinya, order ini perlu ditindaklanjuti admin (refund / pindah kursi).
+type OrderConflict struct {
+	OrderID     int       `json:"order_id" example:"87"`
+	Email       string    `json:"email" example:"rangga@example.com"`
+	OrderStatus string    `json:"order_status" example:"paid"`
+	ScheduleID  int       `json:"schedule_id" example:"12"`
+	Seat        string    `json:"seat" example:"A5"`
+	KeptOrderID int       `json:"kept_order_id" example:"64"`
+	FlaggedAt   time.Time `json:"flagged_at" example:"2025-09-20T10:00:00Z"`
+}
+
+// ExpiredOrder order yang baru saja di-expire oleh worker
+type ExpiredOrder struct {
+	ID         int
+	UserID     *int
+	ScheduleID int
+}
+
+type Ticket struct {
+	OrderID     int
+	UserID      *int
+	ScheduleID  int
+	Status      string
+	BookingCode string
+	QRCode      string
 }
@@ -1,7 +1,47 @@
 package models
 
+import "time"
+
 type PaymentMethod struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	Logo string `json:"logo"`
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Logo     string  `json:"logo"`
+	Provider string  `json:"provider"`
+	BankCode *string `json:"bank_code"`
+}
+
+// Payment satu percobaan pembayaran (charge) untuk sebuah order di provider tertentu
+type Payment struct {
+	ID         int        `json:"id" example:"7"`
+	OrderID    int        `json:"order_id" example:"101"`
+	Provider   string     `json:"provider" example:"mock"`
+	Reference  string     `json:"reference" example:"MOCK-8f2c1d9a7b6e5f40"`
+	Amount     int        `json:"amount" example:"100"`
+	Status     string     `json:"status" example:"pending"`
+	PaymentURL *string    `json:"payment_url" example:"/payment/sandbox/MOCK-8f2c1d9a7b6e5f40"`
+	ExpiresAt  *time.Time `json:"expires_at" example:"2025-09-20T19:45:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-09-20T19:30:00Z"`
+}
+
+// OrderPaymentTarget data order yang dibutuhkan untuk membuat charge
+type OrderPaymentTarget struct {
+	OrderID         int
+	UserID          *int
+	Status          string
+	TotalPrice      int
+	ExpiresAt       *time.Time
+	Name            string
+	Email           string
+	PaymentMethodID int
+	PaymentMethod   string
+	Provider        string
+}
+
+type PaymentSandboxRequest struct {
+	Status string `json:"status" binding:"required,oneof=paid failed expired"`
+}
+
+// VATransferRequest nominal transfer bank yang disimulasikan ke nomor virtual account
+type VATransferRequest struct {
+	Amount int `json:"amount" binding:"required,min=1" example:"150000"`
 }
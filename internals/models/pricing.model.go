@@ -0,0 +1,79 @@
+package models
+
+// PricingRule aturan harga dasar schedule. Kondisi yang kosong berlaku untuk semua schedule.
+// Days memakai ISO day of week (1 = Senin ... 7 = Minggu), StartTime > EndTime berarti melewati tengah malam.
+// Price diisi berarti harga dasar tetap, kosong berarti cinema.price + Modifier.
+// Jika beberapa rule cocok, dipilih Priority tertinggi, lalu rule dengan kondisi terbanyak, lalu rule terbaru.
+type PricingRule struct {
+	ID        int     `json:"id" example:"1"`
+	Name      string  `json:"name" example:"Weekend"`
+	CinemaID  *int    `json:"cinema_id" example:"1"`
+	Days      []int   `json:"days" example:"6,7"`
+	StartTime *string `json:"start_time" example:"17:00:00"`
+	EndTime   *string `json:"end_time" example:"23:45:00"`
+	Holiday   *bool   `json:"holiday" example:"true"`
+	StartDate *string `json:"start_date" example:"2026-12-20"`
+	EndDate   *string `json:"end_date" example:"2027-01-05"`
+	Price     *int    `json:"price" example:"75"`
+	Modifier  int     `json:"modifier" example:"10"`
+	Priority  int     `json:"priority" example:"10"`
+	Active    bool    `json:"active" example:"true"`
+}
+
+type PricingRuleRequest struct {
+	Name      string  `json:"name" binding:"required" example:"Weekend"`
+	CinemaID  *int    `json:"cinema_id" binding:"omitempty,min=1" example:"1"`
+	Days      []int   `json:"days" binding:"omitempty,unique,dive,min=1,max=7" example:"6,7"`
+	StartTime *string `json:"start_time" binding:"omitempty,datetime=15:04:05" example:"17:00:00"`
+	EndTime   *string `json:"end_time" binding:"omitempty,datetime=15:04:05" example:"23:45:00"`
+	Holiday   *bool   `json:"holiday" example:"true"`
+	StartDate *string `json:"start_date" binding:"omitempty,datetime=2006-01-02" example:"2026-12-20"`
+	EndDate   *string `json:"end_date" binding:"omitempty,datetime=2006-01-02" example:"2027-01-05"`
+	Price     *int    `json:"price" binding:"omitempty,min=0" example:"75"`
+	Modifier  int     `json:"modifier" example:"10"`
+	Priority  int     `json:"priority" example:"10"`
+	Active    *bool   `json:"active" example:"true"`
+}
+
+type Holiday struct {
+	ID   int    `json:"id" example:"1"`
+	Date string `json:"date" example:"2026-12-25"`
+	Name string `json:"name" example:"Hari Natal"`
+}
+
+type HolidayRequest struct {
+	Date string `json:"date" binding:"required,datetime=2006-01-02" example:"2026-12-25"`
+	Name string `json:"name" binding:"required" example:"Hari Natal"`
+}
+
+// PricingRuleMatch hasil evaluasi satu rule terhadap schedule, Reasons berisi kondisi yang tidak terpenuhi
+type PricingRuleMatch struct {
+	Rule        PricingRule `json:"rule"`
+	Matched     bool        `json:"matched" example:"true"`
+	Applied     bool        `json:"applied" example:"true"`
+	Specificity int         `json:"specificity" example:"1"`
+	Reasons     []string    `json:"reasons"`
+}
+
+type PricingClassPrice struct {
+	Class string `json:"class" example:"premium"`
+	Name  string `json:"name" example:"Premium"`
+	Price int    `json:"price" example:"70"`
+}
+
+// PricingPreview penjelasan harga satu schedule untuk admin
+type PricingPreview struct {
+	ScheduleID  int                 `json:"schedule_id" example:"1"`
+	CinemaID    int                 `json:"cinema_id" example:"1"`
+	Cinema      string              `json:"cinema" example:"Cineworld"`
+	Date        string              `json:"date" example:"2026-12-26"`
+	Time        string              `json:"time" example:"19:00:00"`
+	DayOfWeek   int                 `json:"day_of_week" example:"6"`
+	Holiday     *string             `json:"holiday" example:"Hari Natal"`
+	CinemaPrice int                 `json:"cinema_price" example:"50"`
+	Price       int                 `json:"price" example:"60"`
+	AppliedRule *PricingRule        `json:"applied_rule"`
+	Explanation string              `json:"explanation" example:"rule #1 Weekend applied: cinema price 50 + modifier 10"`
+	Classes     []PricingClassPrice `json:"classes"`
+	Rules       []PricingRuleMatch  `json:"rules"`
+}
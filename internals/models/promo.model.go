@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Jenis diskon promo code
+const (
+	PromoDiscountPercent = "percent"
+	PromoDiscountFixed   = "fixed"
+)
+
+// PromoCode MovieIDs, CinemaIDs dan PaymentMethodIDs kosong berarti berlaku untuk semua.
+// UsageLimit dan PerUserLimit kosong berarti tidak dibatasi, Used hanya menghitung order yang belum expired / dibatalkan.
+type PromoCode struct {
+	ID               int       `json:"id" example:"1"`
+	Code             string    `json:"code" example:"NONTONHEMAT"`
+	Description      *string   `json:"description" example:"Diskon 20% maksimal 30"`
+	DiscountType     string    `json:"discount_type" example:"percent"`
+	DiscountValue    int       `json:"discount_value" example:"20"`
+	MaxDiscount      *int      `json:"max_discount" example:"30"`
+	MinSpend         int       `json:"min_spend" example:"100"`
+	StartAt          time.Time `json:"start_at" example:"2026-10-01T00:00:00Z"`
+	EndAt            time.Time `json:"end_at" example:"2026-12-31T23:59:59Z"`
+	UsageLimit       *int      `json:"usage_limit" example:"500"`
+	PerUserLimit     *int      `json:"per_user_limit" example:"1"`
+	Active           bool      `json:"active" example:"true"`
+	MovieIDs         []int     `json:"movie_ids" example:"1,2"`
+	CinemaIDs        []int     `json:"cinema_ids" example:"1"`
+	PaymentMethodIDs []int     `json:"payment_method_ids" example:"3"`
+	Used             int       `json:"used" example:"42"`
+}
+
+type PromoCodeRequest struct {
+	Code             string    `json:"code" binding:"required,max=50,alphanum" example:"NONTONHEMAT"`
+	Description      *string   `json:"description" example:"Diskon 20% maksimal 30"`
+	DiscountType     string    `json:"discount_type" binding:"required,oneof=percent fixed" example:"percent"`
+	DiscountValue    int       `json:"discount_value" binding:"required,min=1" example:"20"`
+	MaxDiscount      *int      `json:"max_discount" binding:"omitempty,min=1" example:"30"`
+	MinSpend         int       `json:"min_spend" binding:"min=0" example:"100"`
+	StartAt          time.Time `json:"start_at" binding:"required" example:"2026-10-01T00:00:00Z"`
+	EndAt            time.Time `json:"end_at" binding:"required,gtfield=StartAt" example:"2026-12-31T23:59:59Z"`
+	UsageLimit       *int      `json:"usage_limit" binding:"omitempty,min=1" example:"500"`
+	PerUserLimit     *int      `json:"per_user_limit" binding:"omitempty,min=1" example:"1"`
+	Active           *bool     `json:"active" example:"true"`
+	MovieIDs         []int     `json:"movie_ids" binding:"omitempty,unique,dive,min=1" example:"1,2"`
+	CinemaIDs        []int     `json:"cinema_ids" binding:"omitempty,unique,dive,min=1" example:"1"`
+	PaymentMethodIDs []int     `json:"payment_method_ids" binding:"omitempty,unique,dive,min=1" example:"3"`
+}
+
+// OrderPromo promo yang dipakai pada quote / order
+type OrderPromo struct {
+	Code        string  `json:"code" example:"NONTONHEMAT"`
+	Description *string `json:"description" example:"Diskon 20% maksimal 30"`
+	Discount    int     `json:"discount" example:"20"`
+}
+
+// PromoRejection alasan promo code ditolak, Reason bisa dibaca oleh frontend
+type PromoRejection struct {
+	Code     string `json:"code" example:"NONTONHEMAT"`
+	Reason   string `json:"reason" example:"min_spend"`
+	MinSpend *int   `json:"min_spend,omitempty" example:"100"`
+}
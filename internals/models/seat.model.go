@@ -1,10 +1,73 @@
 package models
 
+import "time"
+
 type Seat struct {
-	ID []string `json:"seat_id"`
+	ID   []string `json:"seat_id"`
+	Held []string `json:"held_seat_id"`
 }
 
+// SeatResponse seat map schedule, seat_id dan held_seat_id tetap dikirim untuk client lama.
+// AccessibleReleased true berarti ruang kursi roda dan kursi pendamping yang belum terjual sudah dibuka untuk umum.
 type SeatResponse struct {
+	ScheduleID         int           `json:"schedule_id" example:"12"`
+	AuditoriumID       int           `json:"auditorium_id" example:"1"`
+	Auditorium         string        `json:"auditorium" example:"Studio 1"`
+	Layout             SeatLayout    `json:"layout"`
+	AccessibleReleased bool          `json:"accessible_released" example:"false"`
+	Seats              []SeatMapSeat `json:"seats"`
+	Seat               []string      `json:"seat_id" example:"A1,A2,A3"`
+	Held               []string      `json:"held_seat_id" example:"B4,B5"`
+}
+
+// SeatHoldRequest Accessible wajib true untuk menahan ruang kursi roda dan kursi pendampingnya
+type SeatHoldRequest struct {
+	Seat       []string `json:"seat" binding:"required,min=1,unique,dive,required"`
+	Accessible bool     `json:"accessible" example:"false"`
+}
+
+// SeatHoldUpdateRequest dipakai release / extend, seat kosong berarti semua hold milik user
+type SeatHoldUpdateRequest struct {
+	Seat []string `json:"seat" binding:"omitempty,unique,dive,required"`
+}
+
+type SeatHoldResponse struct {
+	ScheduleID int       `json:"schedule_id" example:"12"`
+	Seat       []string  `json:"seat" example:"A1,A2"`
+	ExpiresAt  time.Time `json:"expires_at" example:"2025-09-20T19:40:00Z"`
+}
+
+type SeatReleaseResponse struct {
 	ScheduleID int      `json:"schedule_id" example:"12"`
-	Seat       []string `json:"seat_id" example:"A1,A2,A3"`
+	Seat       []string `json:"seat" example:"A1,A2"`
+}
+
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusOffered   = "offered"
+	WaitlistStatusBooked    = "booked"
+	WaitlistStatusExpired   = "expired"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+// WaitlistRequest jumlah kursi yang ditunggu untuk schedule yang sudah habis
+type WaitlistRequest struct {
+	SeatCount int `json:"seat_count" binding:"required,min=1" example:"2"`
+}
+
+// WaitlistEntry antrean user untuk satu schedule. Position hanya diisi selama masih menunggu,
+// Seats dan OfferExpiresAt diisi ketika kursi sudah ditahan untuk user dan harus di-checkout sebelum batas waktunya.
+type WaitlistEntry struct {
+	ID             int        `json:"id" example:"8"`
+	ScheduleID     int        `json:"schedule_id" example:"12"`
+	SeatCount      int        `json:"seat_count" example:"2"`
+	Status         string     `json:"status" example:"offered"`
+	Position       *int       `json:"position" example:"3"`
+	Seats          []string   `json:"seats" example:"D4,D5"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at" example:"2025-09-20T18:10:00Z"`
+	MovieTitle     string     `json:"movie_title" example:"Avengers: Endgame"`
+	CinemaName     string     `json:"cinema_name" example:"XXI Plaza Indonesia"`
+	ShowDate       time.Time  `json:"show_date" example:"2025-09-20T00:00:00Z"`
+	ShowTime       string     `json:"show_time" example:"19:30"`
+	CreatedAt      time.Time  `json:"created_at" example:"2025-09-20T17:00:00Z"`
 }
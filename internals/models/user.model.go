@@ -2,56 +2,103 @@ package models
 
 import "time"
 
+// UserVA nomor virtual account yang masih menunggu transfer untuk order milik user
 type UserVA struct {
-	ID             int    `json:"id"`
-	VirtualAccount string `json:"virtual_account"`
+	Number    string     `json:"number" example:"0140000000000127"`
+	Bank      string     `json:"bank" example:"BCA"`
+	OrderID   int        `json:"order_id" example:"501"`
+	Amount    int        `json:"amount" example:"150000"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-09-20T19:45:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-09-20T19:30:00Z"`
 }
 
 type UserProfile struct {
-	ID             int     `json:"id" example:"101"`
-	FirstName      *string `json:"firstname" example:"Rangga"`
-	LastName       *string `json:"lastname" example:"Saputra"`
-	Phone          *string `json:"phone" example:"+628123456789"`
-	ProfileImg     *string `json:"profileimg" example:"https://example.com/uploads/profile_101.png"`
-	VirtualAccount *string `json:"virtual_account" example:"1234567890123456"`
-	Point          int     `json:"point" example:"250"`
-	Email          string  `json:"email" example:"rangga@example.com"`
-	Role           string  `json:"role" example:"user"`
+	ID         int     `json:"id" example:"101"`
+	FirstName  *string `json:"firstname" example:"Rangga"`
+	LastName   *string `json:"lastname" example:"Saputra"`
+	Phone      *string `json:"phone" example:"+628123456789"`
+	ProfileImg *string `json:"profileimg" example:"https://example.com/uploads/profile_101.png"`
+	Point      int     `json:"point" example:"250"`
+	Email      string  `json:"email" example:"rangga@example.com"`
+	Role       string  `json:"role" example:"user"`
 }
 
 type UpdateProfile struct {
-	ProfileImg     *string `json:"profileimg,omitempty" example:"https://example.com/uploads/profile_101.png"` // akan diisi path setelah upload file
-	FirstName      *string `form:"firstname" example:"Rangga"`
-	LastName       *string `form:"lastname" example:"Saputra"`
-	Phone          *string `form:"phone" example:"+628123456789"`
-	VirtualAccount *string `form:"virtual_account" example:"1234567890123456"`
+	ProfileImg *string `json:"profileimg,omitempty" example:"https://example.com/uploads/profile_101.png"` // akan diisi path setelah upload file
+	FirstName  *string `form:"firstname" example:"Rangga"`
+	LastName   *string `form:"lastname" example:"Saputra"`
+	Phone      *string `form:"phone" example:"+628123456789"`
 }
 
 type OrderHistory struct {
-	OrderID       int       `json:"order_id" example:"501"`
-	IsPaid        bool      `json:"ispaid" example:"true"`
-	TotalPrice    int       `json:"total_price" example:"150000"`
-	QRCode        string    `json:"qrcode" example:"https://example.com/qrcode/501.png"`
-	OrderName     string    `json:"order_name" example:"Rangga Saputra"`
-	OrderEmail    string    `json:"order_email" example:"rangga@example.com"`
-	OrderPhone    string    `json:"order_phone" example:"+628123456789"`
-	PaymentMethod string    `json:"payment_method" example:"Credit Card"`
-	ShowDate      time.Time `json:"show_date" example:"2025-09-20T19:30:00Z"`
-	ShowTime      string    `json:"show_time" example:"19:30"`
-	CinemaName    string    `json:"cinema_name" example:"XXI Plaza Indonesia"`
-	CinemaLogo    string    `json:"cinema_logo" example:"XXI.jpg"`
-	LocationName  string    `json:"location_name" example:"Jakarta"`
-	MovieTitle    string    `json:"movie_title" example:"Avengers: Endgame"`
-	MoviePoster   string    `json:"movie_poster" example:"https://example.com/posters/avengers.jpg"`
-	MovieBackdrop string    `json:"movie_backdrop" example:"https://example.com/backdrops/avengers-bg.jpg"`
-	Duration      int       `json:"duration" example:"180"`
-	Rating        float32   `json:"rating" example:"8.5"`
-	Seats         []*string `json:"seats" example:"[\"A1\",\"A2\",\"A3\"]"`
+	OrderID       int              `json:"order_id" example:"501"`
+	IsPaid        bool             `json:"ispaid" example:"true"`
+	Status        string           `json:"status" example:"paid"`
+	ExpiresAt     *time.Time       `json:"expires_at" example:"2025-09-20T19:45:00Z"`
+	PaidAt        *time.Time       `json:"paid_at" example:"2025-09-20T19:35:00Z"`
+	TotalPrice    int              `json:"total_price" example:"150000"`
+	BookingCode   string           `json:"booking_code" example:"K7QM2XR9TB"`
+	QRCode        string           `json:"qrcode" example:"TKT.501.12.K7QM2XR9TB.3q2-7wX9..."`
+	OrderName     string           `json:"order_name" example:"Rangga Saputra"`
+	OrderEmail    string           `json:"order_email" example:"rangga@example.com"`
+	OrderPhone    string           `json:"order_phone" example:"+628123456789"`
+	PaymentMethod string           `json:"payment_method" example:"Credit Card"`
+	ShowDate      time.Time        `json:"show_date" example:"2025-09-20T19:30:00Z"`
+	ShowTime      string           `json:"show_time" example:"19:30"`
+	CinemaName    string           `json:"cinema_name" example:"XXI Plaza Indonesia"`
+	CinemaLogo    string           `json:"cinema_logo" example:"XXI.jpg"`
+	LocationName  string           `json:"location_name" example:"Jakarta"`
+	MovieTitle    string           `json:"movie_title" example:"Avengers: Endgame"`
+	MoviePoster   string           `json:"movie_poster" example:"https://example.com/posters/avengers.jpg"`
+	MovieBackdrop string           `json:"movie_backdrop" example:"https://example.com/backdrops/avengers-bg.jpg"`
+	Duration      int              `json:"duration" example:"180"`
+	Rating        float32          `json:"rating" example:"8.5"`
+	Seats         []*string        `json:"seats" example:"[\"A1\",\"A2\",\"A3\"]"`
+	Items         []OrderClassItem `json:"items"`
+}
+
+// Pilihan filter dan urutan riwayat order
+const (
+	HistoryUpcoming = "upcoming"
+	HistoryPast     = "past"
+
+	HistorySortNewest       = "newest"
+	HistorySortOldest       = "oldest"
+	HistorySortShowtimeAsc  = "showtime_asc"
+	HistorySortShowtimeDesc = "showtime_desc"
+)
+
+// OrderHistoryQuery query string /user/history, When memisahkan order berdasarkan jam tayang
+type OrderHistoryQuery struct {
+	Page    int    `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=50" example:"10"`
+	When    string `form:"when" binding:"omitempty,oneof=upcoming past" example:"upcoming"`
+	Status  string `form:"status" binding:"omitempty,oneof=pending awaiting_payment paid expired cancelled refunded" example:"paid"`
+	MovieID int    `form:"movie_id" binding:"omitempty,min=1" example:"3"`
+	Sort    string `form:"sort" binding:"omitempty,oneof=newest oldest showtime_asc showtime_desc" example:"newest"`
+}
+
+type HistoryPagination struct {
+	Page       int `json:"page" example:"1"`
+	Limit      int `json:"limit" example:"10"`
+	TotalItems int `json:"total_items" example:"23"`
+	TotalPages int `json:"total_pages" example:"3"`
 }
 
 type OrderHistoryResponse struct {
-	UserID      int            `json:"user_id" example:"101"`
-	ListHistory []OrderHistory `json:"history"`
+	UserID      int               `json:"user_id" example:"101"`
+	ListHistory []OrderHistory    `json:"history"`
+	Pagination  HistoryPagination `json:"pagination"`
+}
+
+// ClaimOrdersRequest booking code order tamu yang akan dipindahkan ke akun user
+type ClaimOrdersRequest struct {
+	BookingCodes []string `json:"booking_codes" binding:"required,min=1,max=50,unique,dive,required" example:"K7QM2XR9TB"`
+}
+
+type ClaimOrdersResult struct {
+	OrderIDs []int    `json:"order_ids" example:"501"`
+	NotFound []string `json:"not_found" example:"ZZZZZZZZZZ"`
 }
 
 type UserPassword struct {
@@ -63,3 +110,32 @@ type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required"`
 	NewPassword string `json:"new_password" binding:"required"`
 }
+
+// PointLedgerEntry satu perubahan saldo poin, Points negatif berarti poin berkurang
+type PointLedgerEntry struct {
+	ID        int       `json:"id" example:"12"`
+	OrderID   *int      `json:"order_id" example:"501"`
+	Points    int       `json:"points" example:"15"`
+	Reason    string    `json:"reason" example:"earn"`
+	CreatedAt time.Time `json:"created_at" example:"2025-09-20T19:35:00Z"`
+}
+
+// UserPoints saldo poin user beserta ledger untuk audit, Balance selalu sama dengan jumlah Points di ledger
+type UserPoints struct {
+	Balance int                `json:"balance" example:"250"`
+	Ledger  []PointLedgerEntry `json:"ledger"`
+}
+
+const (
+	NotificationWaitlistOffer   = "waitlist_offer"
+	NotificationWaitlistExpired = "waitlist_expired"
+)
+
+type Notification struct {
+	ID         int        `json:"id" example:"31"`
+	Type       string     `json:"type" example:"waitlist_offer"`
+	Message    string     `json:"message" example:"Seats D4, D5 for Avengers: Endgame are held for you until 18:10, complete your order before then"`
+	ScheduleID *int       `json:"schedule_id" example:"12"`
+	ReadAt     *time.Time `json:"read_at" example:"2025-09-20T18:02:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-09-20T18:00:00Z"`
+}
@@ -0,0 +1,121 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const MockProviderName = "mock"
+
+// MockProvider sandbox gateway untuk development. Charge disimpan di redis supaya bisa dipakai
+// bersama oleh beberapa instance API, dan statusnya digerakkan manual lewat Simulate.
+type MockProvider struct {
+	Rdb *redis.Client
+}
+
+func NewMockProvider(rdb *redis.Client) *MockProvider {
+	return &MockProvider{Rdb: rdb}
+}
+
+func mockChargeKey(reference string) string {
+	return fmt.Sprintf("Ntisrangga142-MockCharge-%s", reference)
+}
+
+func (p *MockProvider) Name() string {
+	return MockProviderName
+}
+
+func (p *MockProvider) save(ctx context.Context, charge *Charge) error {
+	bt, err := json.Marshal(charge)
+	if err != nil {
+		return err
+	}
+	return p.Rdb.Set(ctx, mockChargeKey(charge.Reference), bt, 24*time.Hour).Err()
+}
+
+func (p *MockProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	reference, err := newReference("MOCK")
+	if err != nil {
+		return nil, err
+	}
+
+	charge := &Charge{
+		Reference:  reference,
+		Provider:   MockProviderName,
+		OrderID:    req.OrderID,
+		Amount:     req.Amount,
+		Status:     ChargeStatusPending,
+		PaymentURL: fmt.Sprintf("/payment/sandbox/%s", reference),
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if err := p.save(ctx, charge); err != nil {
+		return nil, err
+	}
+	return charge, nil
+}
+
+func (p *MockProvider) QueryStatus(ctx context.Context, reference string) (*Charge, error) {
+	bt, err := p.Rdb.Get(ctx, mockChargeKey(reference)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrChargeNotFound
+		}
+		return nil, err
+	}
+
+	var charge Charge
+	if err := json.Unmarshal(bt, &charge); err != nil {
+		return nil, err
+	}
+	return &charge, nil
+}
+
+func (p *MockProvider) Refund(ctx context.Context, reference string, amount int) (*Refund, error) {
+	charge, err := p.QueryStatus(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	if charge.Status != ChargeStatusPaid {
+		return nil, ErrChargeNotPaid
+	}
+
+	// refund sebagian (selisih harga tukar kursi) tidak menutup charge
+	if amount >= charge.Amount {
+		charge.Status = ChargeStatusRefunded
+		if err := p.save(ctx, charge); err != nil {
+			return nil, err
+		}
+	}
+
+	refundRef, err := newReference("MOCKRF")
+	if err != nil {
+		return nil, err
+	}
+	return &Refund{Reference: refundRef, ChargeReference: reference, Amount: amount, Status: ChargeStatusRefunded}, nil
+}
+
+// Simulate mengubah status charge seolah-olah dibayar / gagal di sisi gateway,
+// lalu mengembalikan event webhook yang akan dikirim gateway sungguhan
+func (p *MockProvider) Simulate(ctx context.Context, reference, status string) (*WebhookEvent, error) {
+	charge, err := p.QueryStatus(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	charge.Status = status
+	if err := p.save(ctx, charge); err != nil {
+		return nil, err
+	}
+
+	return &WebhookEvent{
+		Provider:  MockProviderName,
+		Reference: charge.Reference,
+		Status:    charge.Status,
+		Amount:    charge.Amount,
+	}, nil
+}
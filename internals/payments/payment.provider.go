@@ -0,0 +1,121 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status charge yang dikenali semua provider
+const (
+	ChargeStatusPending  = "pending"
+	ChargeStatusPaid     = "paid"
+	ChargeStatusFailed   = "failed"
+	ChargeStatusExpired  = "expired"
+	ChargeStatusRefunded = "refunded"
+)
+
+var (
+	ErrProviderNotFound = errors.New("payment provider not found")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrChargeNotPaid    = errors.New("charge has not been paid")
+)
+
+type ChargeRequest struct {
+	OrderID         int
+	Amount          int
+	PaymentMethodID int
+	PaymentMethod   string
+	CustomerName    string
+	CustomerEmail   string
+	ExpiresAt       time.Time
+}
+
+type Charge struct {
+	Reference  string    `json:"reference"`
+	Provider   string    `json:"provider"`
+	OrderID    int       `json:"order_id"`
+	Amount     int       `json:"amount"`
+	Status     string    `json:"status"`
+	PaymentURL string    `json:"payment_url"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type Refund struct {
+	Reference       string `json:"reference"`
+	ChargeReference string `json:"charge_reference"`
+	Amount          int    `json:"amount"`
+	Status          string `json:"status"`
+}
+
+// WebhookEvent payload callback yang dikirim provider ke POST /payment/webhook
+type WebhookEvent struct {
+	Provider  string `json:"provider"`
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Amount    int    `json:"amount"`
+}
+
+// Provider adapter ke payment gateway. Setiap payment_method memilih adapter lewat kolom provider.
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	QueryStatus(ctx context.Context, reference string) (*Charge, error)
+	Refund(ctx context.Context, reference string, amount int) (*Refund, error)
+}
+
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	reg := &Registry{providers: map[string]Provider{}}
+	for _, p := range providers {
+		reg.Register(p)
+	}
+	return reg
+}
+
+func (r *Registry) Register(p Provider) {
+	r.providers[p.Name()] = p
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
+	}
+	return p, nil
+}
+
+// Sign menghasilkan signature HMAC-SHA256 (hex) dari body webhook
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature membandingkan signature dengan waktu konstan
+func VerifySignature(secret string, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || secret == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// newReference membuat reference acak dengan prefix provider
+func newReference(prefix string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", prefix, hex.EncodeToString(b)), nil
+}
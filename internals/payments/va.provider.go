@@ -0,0 +1,223 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const VirtualAccountProviderName = "va"
+
+var (
+	ErrBankCodeMissing          = errors.New("payment method has no bank code")
+	ErrInvalidVirtualAccount    = errors.New("invalid virtual account number")
+	ErrVirtualAccountClosed     = errors.New("virtual account is no longer accepting transfers")
+	ErrTransferAmountMismatch   = errors.New("transfer amount does not match the virtual account amount")
+	ErrVirtualAccountNotPending = errors.New("virtual account has already been settled")
+)
+
+// VirtualAccountProvider menerbitkan nomor virtual account bank untuk setiap order.
+// Nomor VA sekaligus menjadi reference charge, dan hanya menerima transfer dengan nominal yang sama persis.
+type VirtualAccountProvider struct {
+	DB *pgxpool.Pool
+}
+
+func NewVirtualAccountProvider(db *pgxpool.Pool) *VirtualAccountProvider {
+	return &VirtualAccountProvider{DB: db}
+}
+
+func (p *VirtualAccountProvider) Name() string {
+	return VirtualAccountProviderName
+}
+
+// luhnDigit menghitung check digit Luhn untuk deretan angka
+func luhnDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+// ValidVirtualAccount mengecek format dan check digit nomor VA sebelum dicari ke database
+func ValidVirtualAccount(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+	for _, c := range number {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	body, check := number[:len(number)-1], number[len(number)-1:]
+	return strconv.Itoa(luhnDigit(body)) == check
+}
+
+// vaNumber menyusun nomor VA: kode bank + 12 digit nomor urut + check digit
+func vaNumber(bankCode string, seq int64) string {
+	body := fmt.Sprintf("%s%012d", bankCode, seq)
+	return fmt.Sprintf("%s%d", body, luhnDigit(body))
+}
+
+func (p *VirtualAccountProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	var bankCode *string
+	if err := p.DB.QueryRow(ctx, `SELECT bank_code FROM payment_method WHERE id = $1`, req.PaymentMethodID).Scan(&bankCode); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBankCodeMissing
+		}
+		return nil, err
+	}
+	if bankCode == nil || *bankCode == "" {
+		return nil, ErrBankCodeMissing
+	}
+
+	var seq int64
+	if err := p.DB.QueryRow(ctx, `SELECT nextval('virtual_account_seq')`).Scan(&seq); err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if !req.ExpiresAt.IsZero() {
+		expiresAt = &req.ExpiresAt
+	}
+
+	number := vaNumber(*bankCode, seq)
+	query := `
+		INSERT INTO virtual_account (number, id_payment_method, id_order, amount, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err := p.DB.Exec(ctx, query, number, req.PaymentMethodID, req.OrderID, req.Amount, ChargeStatusPending, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &Charge{
+		Reference: number,
+		Provider:  VirtualAccountProviderName,
+		OrderID:   req.OrderID,
+		Amount:    req.Amount,
+		Status:    ChargeStatusPending,
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+func (p *VirtualAccountProvider) QueryStatus(ctx context.Context, reference string) (*Charge, error) {
+	query := `
+		SELECT id_order, amount, status, expires_at
+		FROM virtual_account
+		WHERE number = $1
+	`
+
+	var expiresAt *time.Time
+	charge := Charge{Reference: reference, Provider: VirtualAccountProviderName}
+	if err := p.DB.QueryRow(ctx, query, reference).Scan(&charge.OrderID, &charge.Amount, &charge.Status, &expiresAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrChargeNotFound
+		}
+		return nil, err
+	}
+	if expiresAt != nil {
+		charge.ExpiresAt = *expiresAt
+		// VA yang lewat batas waktu tidak lagi menerima transfer walau belum ditandai expired
+		if charge.Status == ChargeStatusPending && time.Now().After(*expiresAt) {
+			charge.Status = ChargeStatusExpired
+		}
+	}
+
+	return &charge, nil
+}
+
+func (p *VirtualAccountProvider) Refund(ctx context.Context, reference string, amount int) (*Refund, error) {
+	// refund sebagian (selisih harga tukar kursi) tidak menutup VA
+	query := `
+		UPDATE virtual_account SET status = CASE WHEN $4::int >= amount THEN $1 ELSE status END, update_at = NOW()
+		WHERE number = $2 AND status = $3
+	`
+	cmd, err := p.DB.Exec(ctx, query, ChargeStatusRefunded, reference, ChargeStatusPaid, amount)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.RowsAffected() == 0 {
+		if _, err := p.QueryStatus(ctx, reference); err != nil {
+			return nil, err
+		}
+		return nil, ErrChargeNotPaid
+	}
+
+	refundRef, err := newReference("VARF")
+	if err != nil {
+		return nil, err
+	}
+	return &Refund{Reference: refundRef, ChargeReference: reference, Amount: amount, Status: ChargeStatusRefunded}, nil
+}
+
+// Transfer mensimulasikan transfer bank ke nomor VA. Transfer hanya diterima bila VA masih pending,
+// belum lewat batas waktu, order masih menunggu pembayaran (atau sudah lunas untuk selisih tukar kursi), dan nominalnya sama persis.
+// Hasilnya event webhook yang akan dikirim bank sungguhan.
+func (p *VirtualAccountProvider) Transfer(ctx context.Context, number string, amount int) (*WebhookEvent, error) {
+	if !ValidVirtualAccount(number) {
+		return nil, ErrInvalidVirtualAccount
+	}
+
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT va.amount, va.status, va.expires_at, o.status
+		FROM virtual_account va
+		JOIN orders o ON o.id = va.id_order
+		WHERE va.number = $1
+		FOR UPDATE OF va
+	`
+
+	var vaAmount int
+	var vaStatus, orderStatus string
+	var expiresAt *time.Time
+	if err := tx.QueryRow(ctx, query, number).Scan(&vaAmount, &vaStatus, &expiresAt, &orderStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrChargeNotFound
+		}
+		return nil, err
+	}
+
+	switch {
+	case vaStatus != ChargeStatusPending:
+		return nil, ErrVirtualAccountNotPending
+	case expiresAt != nil && time.Now().After(*expiresAt),
+		orderStatus != models.OrderStatusAwaitingPayment && orderStatus != models.OrderStatusPaid:
+		return nil, ErrVirtualAccountClosed
+	case amount != vaAmount:
+		return nil, ErrTransferAmountMismatch
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE virtual_account SET status = $1, paid_at = NOW(), update_at = NOW() WHERE number = $2`, ChargeStatusPaid, number); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &WebhookEvent{
+		Provider:  VirtualAccountProviderName,
+		Reference: number,
+		Status:    ChargeStatusPaid,
+		Amount:    vaAmount,
+	}, nil
+}
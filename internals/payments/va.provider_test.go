@@ -0,0 +1,49 @@
+package payments
+
+import "testing"
+
+func TestLuhnDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   int
+	}{
+		{digits: "", want: 0},
+		{digits: "0", want: 0},
+		{digits: "7992739871", want: 3},
+		{digits: "411111111111111", want: 1},
+		{digits: "8808000000000001", want: 6},
+		{digits: "9", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.digits, func(t *testing.T) {
+			if got := luhnDigit(tt.digits); got != tt.want {
+				t.Errorf("luhnDigit(%q) = %d, want %d", tt.digits, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidVirtualAccount(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{name: "generated number", number: vaNumber("8808", 1), want: true},
+		{name: "generated number with a long sequence", number: vaNumber("8808", 987654321012), want: true},
+		{name: "wrong check digit", number: "88080000000000015", want: false},
+		{name: "single digit typo", number: "88080000000000026", want: false},
+		{name: "swapped adjacent digits", number: "88080000000000106", want: false},
+		{name: "non digit", number: "8808000000000000A", want: false},
+		{name: "too short", number: "5", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidVirtualAccount(tt.number); got != tt.want {
+				t.Errorf("ValidVirtualAccount(%q) = %v, want %v", tt.number, got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// scheduleAuditorium memilih studio schedule: $6 jika milik cinema $3, atau studio pertama cinema jika $6 = 0.
+// Studio yang tidak cocok menghasilkan NULL sehingga insert ditolak oleh constraint NOT NULL.
+const scheduleAuditorium = `(SELECT id FROM auditorium WHERE id_cinema = $3 AND delete_at IS NULL AND ($6 = 0 OR id = $6) ORDER BY id LIMIT 1)`
+
 type AdminRepo struct {
 	DB *pgxpool.Pool
 }
@@ -271,8 +275,8 @@ func (r *AdminRepo) InsertMovie(ctx context.Context, req models.AdminInsertMovie
 				}
 
 				// insert schedule
-				_, err = tx.Exec(ctx, "INSERT INTO schedule(date, id_movie, id_cinema, id_location, id_time) VALUES($1,$2,$3,$4,$5)",
-					dParsed, movieID, 1, locationID, timeID)
+				_, err = tx.Exec(ctx, "INSERT INTO schedule(date, id_movie, id_cinema, id_location, id_time, id_auditorium) VALUES($1,$2,$3,$4,$5,"+scheduleAuditorium+")",
+					dParsed, movieID, 1, locationID, timeID, 0)
 				if err != nil {
 					return 0, err
 				}
@@ -314,8 +318,8 @@ func (r *AdminRepo) CreateMovieWithRelations(ctx context.Context, m *models.Movi
 	}
 	// schedule
 	for _, c := range combos {
-		_, _ = tx.Exec(ctx, `INSERT INTO schedule (date, id_movie, id_cinema, id_location, id_time, update_at)
-		 VALUES ($1,$2,$3,$4,$5,$6)`, c.Date, movieID, c.IdCinema, c.IdLocation, c.IdTime, now)
+		_, _ = tx.Exec(ctx, `INSERT INTO schedule (date, id_movie, id_cinema, id_location, id_time, update_at, id_auditorium)
+		 VALUES ($1,$2,$3,$4,$5,$7,`+scheduleAuditorium+`)`, c.Date, movieID, c.IdCinema, c.IdLocation, c.IdTime, c.IdAuditorium, now)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -389,10 +393,12 @@ func (r *AdminRepo) GetMovieDetail(ctx context.Context, movieID int) (*models.Ge
 	rows3, err := r.DB.Query(ctx, `
 		SELECT s.id, to_char(s.date,'YYYY-MM-DD'),
 		       c.id, c.name,
+		       a.id, a.name,
 		       l.id, l.name,
 		       t.id, to_char(t.time,'HH24:MI')
 		FROM schedule s
 		JOIN cinema c ON c.id = s.id_cinema
+		JOIN auditorium a ON a.id = s.id_auditorium
 		JOIN location l ON l.id = s.id_location
 		JOIN time t ON t.id = s.id_time
 		WHERE s.id_movie=$1
@@ -403,6 +409,7 @@ func (r *AdminRepo) GetMovieDetail(ctx context.Context, movieID int) (*models.Ge
 			var sc models.ScheduleDetail
 			if err := rows3.Scan(&sc.ID, &sc.Date,
 				&sc.CinemaID, &sc.Cinema,
+				&sc.AuditoriumID, &sc.Auditorium,
 				&sc.LocationID, &sc.Location,
 				&sc.TimeID, &sc.Time); err == nil {
 				movie.Schedules = append(movie.Schedules, sc)
@@ -495,8 +502,8 @@ func (r *AdminRepo) UpdateMovieAdmin(
 		}
 		for _, s := range m.Schedules {
 			_, err = tx.Exec(ctx,
-				"INSERT INTO schedule (id_movie, date, id_cinema, id_location, id_time) VALUES ($1,$2,$3,$4,$5)",
-				movieID, s.Date, s.CinemaID, s.LocationID, s.TimeID,
+				"INSERT INTO schedule (date, id_movie, id_cinema, id_location, id_time, id_auditorium) VALUES ($1,$2,$3,$4,$5,"+scheduleAuditorium+")",
+				s.Date, movieID, s.CinemaID, s.LocationID, s.TimeID, s.AuditoriumID,
 			)
 			if err != nil {
 				return err
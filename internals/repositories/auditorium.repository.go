@@ -0,0 +1,411 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrAuditoriumNotFound = errors.New("auditorium not found")
+	ErrAuditoriumExists   = errors.New("auditorium name already used in this cinema")
+	ErrCinemaNotFound     = errors.New("cinema not found")
+	ErrInvalidLayout      = errors.New("invalid seat layout")
+)
+
+// AuditoriumInUseError dikembalikan ketika perubahan denah / penghapusan studio mengenai kursi yang sudah terjual
+// untuk schedule yang belum tayang
+type AuditoriumInUseError struct {
+	AuditoriumID int
+	Seats        []string
+}
+
+func (e *AuditoriumInUseError) Error() string {
+	if len(e.Seats) == 0 {
+		return fmt.Sprintf("auditorium %d still has upcoming schedules", e.AuditoriumID)
+	}
+	return fmt.Sprintf("auditorium %d has sold seats on upcoming schedules: %s", e.AuditoriumID, strings.Join(e.Seats, ", "))
+}
+
+type AuditoriumRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewAuditoriumRepo(db *pgxpool.Pool) *AuditoriumRepo {
+	return &AuditoriumRepo{DB: db}
+}
+
+// parseSeatPosition memecah id kursi seperti "C12" menjadi label baris dan nomor kolom
+func parseSeatPosition(seat string) (string, int, bool) {
+	if len(seat) < 2 || seat[0] < 'A' || seat[0] > 'Z' {
+		return "", 0, false
+	}
+	col, err := strconv.Atoi(seat[1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return seat[:1], col, true
+}
+
+// adjacentSeats true jika dua kursi bersebelahan di baris yang sama tanpa lorong di antaranya
+func adjacentSeats(layout models.SeatLayout, a, b string) bool {
+	rowA, colA, okA := parseSeatPosition(a)
+	rowB, colB, okB := parseSeatPosition(b)
+	if !okA || !okB || rowA != rowB || (colA-colB != 1 && colB-colA != 1) {
+		return false
+	}
+	return !slices.Contains(layout.Aisles, min(colA, colB))
+}
+
+// layoutSeats menghasilkan semua kursi dari denah, posisi gap dilewati dan kursi disabled tetap dibuat.
+// Kursi kelas pairs_only dipasangkan berurutan dari kiri dalam satu blok kursi yang bersebelahan.
+func layoutSeats(layout models.SeatLayout, classes map[string]models.SeatClass) ([]models.AuditoriumSeat, error) {
+	inLayout := func(seat string) bool {
+		row, col, ok := parseSeatPosition(seat)
+		return ok && int(row[0]-'A') < layout.Rows && col >= 1 && col <= layout.Columns
+	}
+
+	for _, aisle := range layout.Aisles {
+		if aisle >= layout.Columns {
+			return nil, fmt.Errorf("%w: aisle %d must be between column 1 and %d", ErrInvalidLayout, aisle, layout.Columns-1)
+		}
+	}
+	for _, seat := range slices.Concat(layout.Gaps, layout.Disabled, layout.Wheelchair, layout.Companion) {
+		if !inLayout(seat) {
+			return nil, fmt.Errorf("%w: %s is outside the layout", ErrInvalidLayout, seat)
+		}
+	}
+	for _, seat := range layout.Disabled {
+		if slices.Contains(layout.Gaps, seat) {
+			return nil, fmt.Errorf("%w: %s cannot be both a gap and disabled", ErrInvalidLayout, seat)
+		}
+	}
+	for _, seat := range slices.Concat(layout.Wheelchair, layout.Companion) {
+		if slices.Contains(layout.Gaps, seat) || slices.Contains(layout.Disabled, seat) {
+			return nil, fmt.Errorf("%w: %s must be a sellable seat to be a wheelchair space or companion seat", ErrInvalidLayout, seat)
+		}
+	}
+	for _, seat := range layout.Companion {
+		if slices.Contains(layout.Wheelchair, seat) {
+			return nil, fmt.Errorf("%w: %s cannot be both a wheelchair space and a companion seat", ErrInvalidLayout, seat)
+		}
+		if !slices.ContainsFunc(layout.Wheelchair, func(w string) bool { return adjacentSeats(layout, seat, w) }) {
+			return nil, fmt.Errorf("%w: companion seat %s must be next to a wheelchair space", ErrInvalidLayout, seat)
+		}
+	}
+
+	regular, ok := classes[models.SeatClassRegular]
+	if !ok {
+		return nil, fmt.Errorf("%w: seat class %s is missing", ErrInvalidLayout, models.SeatClassRegular)
+	}
+	seatClass := map[string]models.SeatClass{}
+	for _, area := range layout.Classes {
+		class, ok := classes[area.Class]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown seat class %s", ErrInvalidLayout, area.Class)
+		}
+		for _, row := range area.Rows {
+			if len(row) != 1 || !inLayout(row+"1") {
+				return nil, fmt.Errorf("%w: row %s is outside the layout", ErrInvalidLayout, row)
+			}
+			for col := 1; col <= layout.Columns; col++ {
+				seatClass[fmt.Sprintf("%s%d", row, col)] = class
+			}
+		}
+		for _, seat := range area.Seats {
+			if !inLayout(seat) {
+				return nil, fmt.Errorf("%w: %s is outside the layout", ErrInvalidLayout, seat)
+			}
+			seatClass[seat] = class
+		}
+	}
+
+	seats := make([]models.AuditoriumSeat, 0, layout.Rows*layout.Columns)
+	for r := 0; r < layout.Rows; r++ {
+		row := string(rune('A' + r))
+		var pending *models.AuditoriumSeat
+		for col := 1; col <= layout.Columns; col++ {
+			id := fmt.Sprintf("%s%d", row, col)
+			if slices.Contains(layout.Gaps, id) {
+				if pending != nil {
+					return nil, fmt.Errorf("%w: %s has no pair", ErrInvalidLayout, pending.ID)
+				}
+				continue
+			}
+
+			class, ok := seatClass[id]
+			if !ok {
+				class = regular
+			}
+			seat := models.AuditoriumSeat{
+				ID:          id,
+				Row:         row,
+				Column:      col,
+				Disabled:    slices.Contains(layout.Disabled, id),
+				SeatClassID: class.ID,
+				Class:       class.Code,
+			}
+			switch {
+			case slices.Contains(layout.Wheelchair, id):
+				access := models.SeatAccessWheelchair
+				seat.Access = &access
+			case slices.Contains(layout.Companion, id):
+				access := models.SeatAccessCompanion
+				seat.Access = &access
+			}
+			if seat.Access != nil && class.PairsOnly {
+				return nil, fmt.Errorf("%w: %s cannot be a couple seat and an accessible seat", ErrInvalidLayout, id)
+			}
+
+			switch {
+			case pending != nil && class.PairsOnly && pending.Class == seat.Class:
+				if pending.Disabled != seat.Disabled {
+					return nil, fmt.Errorf("%w: %s and %s must both be enabled or disabled", ErrInvalidLayout, pending.ID, seat.ID)
+				}
+				pending.Pair, seat.Pair = &seat.ID, &pending.ID
+				seats = append(seats, *pending, seat)
+				pending = nil
+			case pending != nil:
+				return nil, fmt.Errorf("%w: %s has no pair", ErrInvalidLayout, pending.ID)
+			case class.PairsOnly:
+				pending = &seat
+			default:
+				seats = append(seats, seat)
+			}
+
+			// lorong memutus blok kursi sehingga pasangan tidak boleh menyeberang
+			if pending != nil && slices.Contains(layout.Aisles, col) {
+				return nil, fmt.Errorf("%w: %s has no pair", ErrInvalidLayout, pending.ID)
+			}
+		}
+		if pending != nil {
+			return nil, fmt.Errorf("%w: %s has no pair", ErrInvalidLayout, pending.ID)
+		}
+	}
+	if len(seats) == 0 {
+		return nil, fmt.Errorf("%w: layout has no seats", ErrInvalidLayout)
+	}
+	return seats, nil
+}
+
+const auditoriumColumns = `
+	a.id, a.id_cinema, a.name, a.layout,
+	(SELECT COUNT(*) FROM auditorium_seat s WHERE s.id_auditorium = a.id AND NOT s.disabled)
+`
+
+func scanAuditorium(row pgx.Row) (models.Auditorium, error) {
+	var a models.Auditorium
+	err := row.Scan(&a.ID, &a.CinemaID, &a.Name, &a.Layout, &a.Capacity)
+	return a, err
+}
+
+func (r *AuditoriumRepo) GetByCinema(ctx context.Context, cinemaID int) ([]models.Auditorium, error) {
+	query := fmt.Sprintf(`SELECT %s FROM auditorium a WHERE a.id_cinema = $1 AND a.delete_at IS NULL ORDER BY a.id`, auditoriumColumns)
+
+	rows, err := r.DB.Query(ctx, query, cinemaID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Auditorium, error) {
+		return scanAuditorium(row)
+	})
+}
+
+func (r *AuditoriumRepo) GetByID(ctx context.Context, id int) (*models.Auditorium, error) {
+	return getAuditorium(ctx, r.DB, id)
+}
+
+func getAuditorium(ctx context.Context, db querier, id int) (*models.Auditorium, error) {
+	query := fmt.Sprintf(`SELECT %s FROM auditorium a WHERE a.id = $1 AND a.delete_at IS NULL`, auditoriumColumns)
+
+	a, err := scanAuditorium(db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuditoriumNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+// isUniqueViolation cek error unique constraint dari postgres
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (r *AuditoriumRepo) Create(ctx context.Context, cinemaID int, req models.AuditoriumRequest) (*models.Auditorium, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	classes, err := seatClassesByCode(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	seats, err := layoutSeats(req.Layout, classes)
+	if err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM cinema WHERE id = $1)`, cinemaID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrCinemaNotFound
+	}
+
+	var id int
+	err = tx.QueryRow(ctx, `INSERT INTO auditorium (id_cinema, name, layout) VALUES ($1, $2, $3) RETURNING id`, cinemaID, req.Name, req.Layout).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrAuditoriumExists
+		}
+		return nil, err
+	}
+
+	if err := syncAuditoriumSeats(ctx, tx, id, seats); err != nil {
+		return nil, err
+	}
+
+	auditorium, err := getAuditorium(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return auditorium, nil
+}
+
+// Update mengganti nama dan denah studio. Kursi yang masih ada di denah baru tetap dipertahankan,
+// kursi yang dihapus ditolak jika sudah terjual untuk schedule yang belum tayang.
+func (r *AuditoriumRepo) Update(ctx context.Context, id int, req models.AuditoriumRequest) (*models.Auditorium, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	classes, err := seatClassesByCode(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	seats, err := layoutSeats(req.Layout, classes)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE auditorium SET name = $1, layout = $2, update_at = NOW() WHERE id = $3 AND delete_at IS NULL`
+	tag, err := tx.Exec(ctx, query, req.Name, req.Layout, id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrAuditoriumExists
+		}
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrAuditoriumNotFound
+	}
+
+	if err := syncAuditoriumSeats(ctx, tx, id, seats); err != nil {
+		return nil, err
+	}
+
+	auditorium, err := getAuditorium(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return auditorium, nil
+}
+
+// syncAuditoriumSeats menyamakan auditorium_seat dengan hasil denah
+func syncAuditoriumSeats(ctx context.Context, tx pgx.Tx, auditoriumID int, seats []models.AuditoriumSeat) error {
+	ids := make([]string, 0, len(seats))
+	rowLabels := make([]string, 0, len(seats))
+	columns := make([]int, 0, len(seats))
+	disabled := make([]bool, 0, len(seats))
+	classIDs := make([]int, 0, len(seats))
+	pairs := make([]*string, 0, len(seats))
+	access := make([]*string, 0, len(seats))
+	for _, s := range seats {
+		ids = append(ids, s.ID)
+		rowLabels = append(rowLabels, s.Row)
+		columns = append(columns, s.Column)
+		disabled = append(disabled, s.Disabled)
+		classIDs = append(classIDs, s.SeatClassID)
+		pairs = append(pairs, s.Pair)
+		access = append(access, s.Access)
+	}
+
+	query := `
+		SELECT DISTINCT od.id_seat
+		FROM orderdetails od
+		JOIN schedule s ON s.id = od.id_schedule
+		WHERE s.id_auditorium = $1 AND s.date >= CURRENT_DATE AND od.released_at IS NULL
+			AND od.id_seat <> ALL($2::varchar[])
+		ORDER BY od.id_seat;
+	`
+	rows, err := tx.Query(ctx, query, auditoriumID, ids)
+	if err != nil {
+		return err
+	}
+	sold, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	if len(sold) > 0 {
+		return &AuditoriumInUseError{AuditoriumID: auditoriumID, Seats: sold}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM auditorium_seat WHERE id_auditorium = $1 AND id_seat <> ALL($2::varchar[])`, auditoriumID, ids); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO auditorium_seat (id_auditorium, id_seat, row_label, col_number, disabled, id_seat_class, pair_seat, access)
+		SELECT $1, UNNEST($2::varchar[]), UNNEST($3::varchar[]), UNNEST($4::int[]), UNNEST($5::bool[]), UNNEST($6::int[]), UNNEST($7::varchar[]), UNNEST($8::varchar[])
+		ON CONFLICT (id_auditorium, id_seat) DO UPDATE
+		SET row_label = EXCLUDED.row_label, col_number = EXCLUDED.col_number, disabled = EXCLUDED.disabled,
+			id_seat_class = EXCLUDED.id_seat_class, pair_seat = EXCLUDED.pair_seat, access = EXCLUDED.access;
+	`
+	_, err = tx.Exec(ctx, query, auditoriumID, ids, rowLabels, columns, disabled, classIDs, pairs, access)
+	return err
+}
+
+// Delete soft delete studio, ditolak jika masih dipakai schedule yang belum tayang
+func (r *AuditoriumRepo) Delete(ctx context.Context, id int) error {
+	var upcoming bool
+	query := `SELECT EXISTS (SELECT 1 FROM schedule WHERE id_auditorium = $1 AND date >= CURRENT_DATE AND delete_at IS NULL)`
+	if err := r.DB.QueryRow(ctx, query, id).Scan(&upcoming); err != nil {
+		return err
+	}
+	if upcoming {
+		return &AuditoriumInUseError{AuditoriumID: id}
+	}
+
+	tag, err := r.DB.Exec(ctx, `UPDATE auditorium SET delete_at = NOW() WHERE id = $1 AND delete_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAuditoriumNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+)
+
+// describeSeat ringkasan kursi hasil layoutSeats supaya hasil test mudah dibandingkan
+func describeSeat(seat models.AuditoriumSeat) string {
+	desc := seat.ID + " " + seat.Class
+	if seat.Pair != nil {
+		desc += " pair " + *seat.Pair
+	}
+	if seat.Access != nil {
+		desc += " " + *seat.Access
+	}
+	if seat.Disabled {
+		desc += " disabled"
+	}
+	return desc
+}
+
+func TestLayoutSeats(t *testing.T) {
+	classes := map[string]models.SeatClass{
+		models.SeatClassRegular: {ID: 1, Code: models.SeatClassRegular},
+		"couple":                {ID: 3, Code: "couple", PairsOnly: true},
+	}
+	coupleRowA := []models.SeatClassArea{{Class: "couple", Rows: []string{"A"}}}
+
+	tests := []struct {
+		name    string
+		layout  models.SeatLayout
+		classes map[string]models.SeatClass
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "gaps are skipped and disabled seats are kept",
+			layout: models.SeatLayout{Rows: 1, Columns: 4, Gaps: []string{"A2"}, Disabled: []string{"A4"}},
+			want:   []string{"A1 regular", "A3 regular", "A4 regular disabled"},
+		},
+		{
+			name:    "aisle after the last column",
+			layout:  models.SeatLayout{Rows: 1, Columns: 4, Aisles: []int{4}},
+			wantErr: true,
+		},
+		{
+			name:   "aisle before the last column",
+			layout: models.SeatLayout{Rows: 1, Columns: 2, Aisles: []int{1}},
+			want:   []string{"A1 regular", "A2 regular"},
+		},
+		{
+			name:    "gap outside the layout",
+			layout:  models.SeatLayout{Rows: 1, Columns: 4, Gaps: []string{"B1"}},
+			wantErr: true,
+		},
+		{
+			name:   "couple seats are paired from the left",
+			layout: models.SeatLayout{Rows: 1, Columns: 4, Classes: coupleRowA},
+			want:   []string{"A1 couple pair A2", "A2 couple pair A1", "A3 couple pair A4", "A4 couple pair A3"},
+		},
+		{
+			name:   "couple seats are paired within each aisle block",
+			layout: models.SeatLayout{Rows: 1, Columns: 4, Aisles: []int{2}, Classes: coupleRowA},
+			want:   []string{"A1 couple pair A2", "A2 couple pair A1", "A3 couple pair A4", "A4 couple pair A3"},
+		},
+		{
+			name:    "couple pair across an aisle",
+			layout:  models.SeatLayout{Rows: 1, Columns: 4, Aisles: []int{1}, Classes: coupleRowA},
+			wantErr: true,
+		},
+		{
+			name:    "couple pair across a gap",
+			layout:  models.SeatLayout{Rows: 1, Columns: 4, Gaps: []string{"A2"}, Classes: coupleRowA},
+			wantErr: true,
+		},
+		{
+			name:    "couple row with an odd number of seats",
+			layout:  models.SeatLayout{Rows: 1, Columns: 3, Classes: coupleRowA},
+			wantErr: true,
+		},
+		{
+			name:    "couple pair with one disabled seat",
+			layout:  models.SeatLayout{Rows: 1, Columns: 2, Disabled: []string{"A2"}, Classes: coupleRowA},
+			wantErr: true,
+		},
+		{
+			name:   "companion seat next to a wheelchair space",
+			layout: models.SeatLayout{Rows: 1, Columns: 3, Wheelchair: []string{"A1"}, Companion: []string{"A2"}},
+			want:   []string{"A1 regular wheelchair", "A2 regular companion", "A3 regular"},
+		},
+		{
+			name:    "companion seat across an aisle",
+			layout:  models.SeatLayout{Rows: 1, Columns: 2, Aisles: []int{1}, Wheelchair: []string{"A1"}, Companion: []string{"A2"}},
+			wantErr: true,
+		},
+		{
+			name:    "regular class is missing",
+			layout:  models.SeatLayout{Rows: 1, Columns: 2},
+			classes: map[string]models.SeatClass{},
+			wantErr: true,
+		},
+		{
+			name:    "every seat is a gap",
+			layout:  models.SeatLayout{Rows: 1, Columns: 1, Gaps: []string{"A1"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.classes == nil {
+				tt.classes = classes
+			}
+			seats, err := layoutSeats(tt.layout, tt.classes)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidLayout) {
+					t.Fatalf("layoutSeats() error = %v, want %v", err, ErrInvalidLayout)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("layoutSeats() error = %v", err)
+			}
+
+			got := make([]string, 0, len(seats))
+			for _, seat := range seats {
+				got = append(got, describeSeat(seat))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("layoutSeats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrStaffNotFound     = errors.New("staff is not assigned to any cinema")
+	ErrTicketNotFound    = errors.New("ticket not found")
+	ErrTicketNotPaid     = errors.New("ticket has not been paid")
+	ErrTicketWrongCinema = errors.New("ticket is for another cinema")
+	ErrTicketNotToday    = errors.New("ticket is not for today's schedule")
+)
+
+// TicketUsedError dikembalikan ketika tiket sudah pernah di-check in
+type TicketUsedError struct {
+	OrderID     int
+	BookingCode string
+	CheckinAt   time.Time
+}
+
+func (e *TicketUsedError) Error() string {
+	return fmt.Sprintf("ticket already used at %s", e.CheckinAt.Format(time.RFC3339))
+}
+
+type CheckinRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewCheckinRepo(db *pgxpool.Pool) *CheckinRepo {
+	return &CheckinRepo{DB: db}
+}
+
+// CheckIn menandai tiket sudah dipakai. orderID diisi jika code berasal dari QR yang sudah diverifikasi,
+// supaya QR lama tidak bisa dipakai setelah booking code diganti.
+func (r *CheckinRepo) CheckIn(ctx context.Context, staffID int, bookingCode string, orderID int) (*models.CheckinResponse, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var cinemaID int
+	if err := tx.QueryRow(ctx, `SELECT id_cinema FROM staff WHERE id = $1`, staffID).Scan(&cinemaID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			o.id, o.booking_code, o.name, o.status, o.checkin_at,
+			s.id_cinema, s.date = CURRENT_DATE AS is_today,
+			m.title, c.name, l.name, s.date, t.time::text
+		FROM orders o
+		JOIN schedule s ON s.id = o.id_schedule
+		JOIN movies m ON m.id = s.id_movie
+		JOIN cinema c ON c.id = s.id_cinema
+		JOIN location l ON l.id = s.id_location
+		JOIN time t ON t.id = s.id_time
+		WHERE o.booking_code = $1
+		FOR UPDATE OF o;
+	`
+
+	var (
+		res              models.CheckinResponse
+		status           string
+		checkinAt        *time.Time
+		scheduleCinemaID int
+		isToday          bool
+	)
+	err = tx.QueryRow(ctx, query, bookingCode).Scan(
+		&res.OrderID, &res.BookingCode, &res.Name, &status, &checkinAt,
+		&scheduleCinemaID, &isToday,
+		&res.Title, &res.Cinema, &res.Location, &res.Date, &res.Time,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, err
+	}
+	if orderID != 0 && orderID != res.OrderID {
+		return nil, ErrTicketNotFound
+	}
+
+	// staff bioskop lain tidak boleh tahu apakah tiket sudah dipakai
+	if scheduleCinemaID != cinemaID {
+		return nil, ErrTicketWrongCinema
+	}
+	if checkinAt != nil {
+		return nil, &TicketUsedError{OrderID: res.OrderID, BookingCode: res.BookingCode, CheckinAt: *checkinAt}
+	}
+	if status != models.OrderStatusPaid {
+		return nil, ErrTicketNotPaid
+	}
+	if !isToday {
+		return nil, ErrTicketNotToday
+	}
+
+	err = tx.QueryRow(ctx, `UPDATE orders SET checkin_at = NOW(), checkin_by = $1 WHERE id = $2 RETURNING checkin_at`, staffID, res.OrderID).Scan(&res.CheckinAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE orderdetails SET checkin_at = $1
+		WHERE id_order = $2 AND released_at IS NULL
+		RETURNING id_seat;
+	`, res.CheckinAt, res.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	res.Seat, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
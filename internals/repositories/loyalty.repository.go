@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// Alasan perubahan poin di point_ledger
+const (
+	PointReasonEarn    = "earn"
+	PointReasonRedeem  = "redeem"
+	PointReasonReverse = "reverse"
+	PointReasonAdjust  = "adjust"
+)
+
+// InsufficientPointsError dikembalikan ketika poin yang ingin ditukar melebihi saldo user
+type InsufficientPointsError struct {
+	Balance   int
+	Requested int
+}
+
+func (e *InsufficientPointsError) Error() string {
+	return fmt.Sprintf("insufficient points: balance %d, requested %d", e.Balance, e.Requested)
+}
+
+// addPoints mencatat perubahan poin ke ledger dan menyesuaikan saldo users.point
+func addPoints(ctx context.Context, db querier, userID int, orderID *int, points int, reason string) error {
+	query := `INSERT INTO point_ledger (id_user, id_order, points, reason) VALUES ($1, $2, $3, $4)`
+	if _, err := db.Exec(ctx, query, userID, orderID, points, reason); err != nil {
+		return err
+	}
+	_, err := db.Exec(ctx, `UPDATE users SET point = COALESCE(point, 0) + $1 WHERE id = $2`, points, userID)
+	return err
+}
+
+// reverseOrderPoints membatalkan semua poin yang didapat / dipakai oleh order, sehingga saldo ledger order menjadi 0
+func reverseOrderPoints(ctx context.Context, db querier, orderID int) error {
+	query := `
+		SELECT id_user, SUM(points)::int
+		FROM point_ledger
+		WHERE id_order = $1
+		GROUP BY id_user
+		HAVING SUM(points) <> 0;
+	`
+
+	rows, err := db.Query(ctx, query, orderID)
+	if err != nil {
+		return err
+	}
+	type balance struct {
+		UserID int
+		Points int
+	}
+	balances, err := pgx.CollectRows(rows, pgx.RowToStructByPos[balance])
+	if err != nil {
+		return err
+	}
+
+	for _, b := range balances {
+		if err := addPoints(ctx, db, b.UserID, &orderID, -b.Points, PointReasonReverse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPoints menukar poin sebagai potongan dari sisa total quote.
+// Poin yang dipakai dibatasi sisa total sehingga tidak ada poin yang terbuang, mengembalikan jumlah poin yang dipakai.
+func applyPoints(quote *models.OrderQuote, points, pointValue int) int {
+	used := min(points, quote.Total/pointValue)
+	quote.PointsUsed = used
+	quote.PointsDiscount = used * pointValue
+	quote.Discount += quote.PointsDiscount
+	quote.Total = quote.Subtotal - quote.Discount
+	return used
+}
+
+// lockPointBalance mengunci baris user selama transaction order supaya saldo tidak bisa dipakai dua kali
+func lockPointBalance(ctx context.Context, tx pgx.Tx, userID, requested int) error {
+	var balance int
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(point, 0) FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &InsufficientPointsError{Balance: 0, Requested: requested}
+		}
+		return err
+	}
+	if balance < requested {
+		return &InsufficientPointsError{Balance: balance, Requested: requested}
+	}
+	return nil
+}
+
+// earnOrderPoints mengkreditkan poin dari total yang dibayar, order tanpa user (guest) tidak mendapat poin
+func earnOrderPoints(ctx context.Context, db querier, orderID, earnRate int) error {
+	var (
+		userID *int
+		total  int
+	)
+	if err := db.QueryRow(ctx, `SELECT id_user, total_price FROM orders WHERE id = $1`, orderID).Scan(&userID, &total); err != nil {
+		return err
+	}
+
+	points := total * earnRate / 100
+	if userID == nil || points <= 0 {
+		return nil
+	}
+	return addPoints(ctx, db, *userID, &orderID, points, PointReasonEarn)
+}
+
+// adjustOrderPoints menyesuaikan poin order lunas yang totalnya berubah sebesar difference, selisih negatif mengurangi poin
+func adjustOrderPoints(ctx context.Context, db querier, orderID, difference, earnRate int) error {
+	var userID *int
+	if err := db.QueryRow(ctx, `SELECT id_user FROM orders WHERE id = $1`, orderID).Scan(&userID); err != nil {
+		return err
+	}
+
+	points := difference * earnRate / 100
+	if userID == nil || points == 0 {
+		return nil
+	}
+	return addPoints(ctx, db, *userID, &orderID, points, PointReasonAdjust)
+}
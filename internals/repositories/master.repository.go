@@ -3,8 +3,10 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -89,7 +91,7 @@ func (r *MasterRepo) GetTimes(ctx context.Context) ([]models.MasterTime, error)
 }
 
 func (r *MasterRepo) GetCinemas(ctx context.Context) ([]models.MasterCinema, error) {
-	rows, err := r.DB.Query(ctx, "SELECT id, name, logo, price FROM cinema")
+	rows, err := r.DB.Query(ctx, "SELECT id, name, logo, price, no_orphan_seat FROM cinema")
 	if err != nil {
 		return nil, err
 	}
@@ -98,10 +100,28 @@ func (r *MasterRepo) GetCinemas(ctx context.Context) ([]models.MasterCinema, err
 	var cinemas []models.MasterCinema
 	for rows.Next() {
 		var c models.MasterCinema
-		if err := rows.Scan(&c.ID, &c.Name, &c.Logo, &c.Price); err != nil {
+		if err := rows.Scan(&c.ID, &c.Name, &c.Logo, &c.Price, &c.NoOrphanSeat); err != nil {
 			return nil, err
 		}
 		cinemas = append(cinemas, c)
 	}
 	return cinemas, nil
 }
+
+// UpdateSeatRules mengubah aturan pemilihan kursi bioskop, berlaku untuk hold dan order berikutnya
+func (r *MasterRepo) UpdateSeatRules(ctx context.Context, cinemaID int, req models.CinemaSeatRulesRequest) (*models.CinemaSeatRules, error) {
+	query := `
+		UPDATE cinema SET no_orphan_seat = $1, update_at = NOW()
+		WHERE id = $2
+		RETURNING id, no_orphan_seat
+	`
+
+	var rules models.CinemaSeatRules
+	if err := r.DB.QueryRow(ctx, query, *req.NoOrphanSeat, cinemaID).Scan(&rules.CinemaID, &rules.NoOrphanSeat); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCinemaNotFound
+		}
+		return nil, err
+	}
+	return &rules, nil
+}
@@ -2,11 +2,82 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
+	"github.com/Ntisrangga142/API_tickytiz/pkg"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// querier dipenuhi oleh *pgxpool.Pool maupun pgx.Tx, supaya query yang sama bisa dipakai di dalam / luar transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// SeatConflictError dikembalikan ketika satu atau lebih kursi sudah terjual untuk schedule yang sama
+type SeatConflictError struct {
+	ScheduleID int
+	Seats      []string
+}
+
+func (e *SeatConflictError) Error() string {
+	return fmt.Sprintf("seat already booked for schedule %d: %s", e.ScheduleID, strings.Join(e.Seats, ", "))
+}
+
+var (
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrPaymentDeadlinePassed = errors.New("payment deadline has passed")
+	ErrPaymentNotFound       = errors.New("payment not found")
+	ErrPaymentAmountMismatch = errors.New("payment amount does not match")
+	ErrCancelCutoffPassed    = errors.New("order can no longer be cancelled this close to the showtime")
+	ErrRefundNotFound        = errors.New("refund not found")
+	ErrGuestPoints           = errors.New("points can only be redeemed by logged-in users")
+	ErrStatusNeedsCancel     = errors.New("orders can only be cancelled or refunded through the cancel endpoint")
+	ErrOrderCheckedIn        = errors.New("order has already been checked in")
+)
+
+// orderTransitions daftar status tujuan yang boleh dari setiap status order
+var orderTransitions = map[string][]string{
+	models.OrderStatusPending:         {models.OrderStatusAwaitingPayment, models.OrderStatusPaid, models.OrderStatusExpired, models.OrderStatusCancelled},
+	models.OrderStatusAwaitingPayment: {models.OrderStatusPaid, models.OrderStatusExpired, models.OrderStatusCancelled},
+	models.OrderStatusPaid:            {models.OrderStatusCancelled, models.OrderStatusRefunded},
+	models.OrderStatusCancelled:       {models.OrderStatusRefunded},
+}
+
+// orderStatusColumns kolom timestamp yang diisi saat order masuk ke status tersebut
+var orderStatusColumns = map[string]string{
+	models.OrderStatusAwaitingPayment: "awaiting_payment_at",
+	models.OrderStatusPaid:            "paid_at",
+	models.OrderStatusExpired:         "expired_at",
+	models.OrderStatusCancelled:       "cancelled_at",
+	models.OrderStatusRefunded:        "refunded_at",
+}
+
+// InvalidTransitionError dikembalikan ketika perpindahan status order tidak diizinkan
+type InvalidTransitionError struct {
+	OrderID int
+	From    string
+	To      string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("order %d cannot move from %s to %s", e.OrderID, e.From, e.To)
+}
+
+// Allowed status tujuan yang sebenarnya diizinkan dari status saat ini
+func (e *InvalidTransitionError) Allowed() []string {
+	return orderTransitions[e.From]
+}
+
 type OrderRepo struct {
 	DB *pgxpool.Pool
 }
@@ -15,47 +86,997 @@ func NewOrderRepo(db *pgxpool.Pool) *OrderRepo {
 	return &OrderRepo{DB: db}
 }
 
-func (r *OrderRepo) CreateOrder(ctx context.Context, req models.OrderRequest, userID int) (*models.OrderResponse, error) {
+// Quote menghitung rincian harga order tanpa menyimpan apapun. userID 0 berarti quote tanpa login.
+// Promo dipotong lebih dulu, lalu poin menutup sisa total.
+func (r *OrderRepo) Quote(ctx context.Context, req models.OrderQuoteRequest, userID, pointValue int) (*models.OrderQuote, error) {
+	quote, err := r.quote(ctx, r.DB, req.ScheduleID, req.Seat)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PromoCode != "" {
+		check := promoCheck{Code: req.PromoCode, ScheduleID: req.ScheduleID, PaymentMethodID: req.PaymentMethodID, UserID: userID}
+		if _, err := applyPromo(ctx, r.DB, check, quote, false); err != nil {
+			return nil, err
+		}
+	}
+	if req.Points > 0 {
+		applyPoints(quote, req.Points, pointValue)
+	}
+	return quote, nil
+}
+
+// quote menghitung harga setiap kursi sesuai kelasnya lalu merangkumnya per kelas
+func (r *OrderRepo) quote(ctx context.Context, db querier, scheduleID int, seats []string) (*models.OrderQuote, error) {
+	if err := validateSeats(ctx, db, scheduleID, seats); err != nil {
+		return nil, err
+	}
+
+	prices, err := seatPrices(ctx, db, scheduleID, seats)
+	if err != nil {
+		return nil, err
+	}
+	bySeat := make(map[string]seatPrice, len(prices))
+	for _, p := range prices {
+		bySeat[p.ID] = p
+	}
+
+	res := models.OrderQuote{ScheduleID: scheduleID}
+	for _, seat := range seats {
+		p := bySeat[seat]
+		res.UnitPrice = p.BasePrice
+		res.Items = append(res.Items, models.OrderQuoteItem{Seat: seat, Class: p.Class, SeatClassID: p.SeatClassID, Price: p.Price})
+		res.Subtotal += p.Price
+	}
+	res.Classes = summariseClasses(prices)
+	res.Total = res.Subtotal
+
+	return &res, nil
+}
+
+// summariseClasses mengelompokkan kursi per kelas dan harga untuk rincian quote / struk
+func summariseClasses(prices []seatPrice) []models.OrderClassItem {
+	var classes []models.OrderClassItem
+	for _, p := range prices {
+		i := slices.IndexFunc(classes, func(c models.OrderClassItem) bool {
+			return c.Class == p.Class && c.UnitPrice == p.Price
+		})
+		if i < 0 {
+			classes = append(classes, models.OrderClassItem{Class: p.Class, ClassName: p.ClassName, UnitPrice: p.Price})
+			i = len(classes) - 1
+		}
+		classes[i].Seats = append(classes[i].Seats, p.ID)
+		classes[i].Quantity++
+		classes[i].Subtotal += p.Price
+	}
+	return classes
+}
+
+// CreateOrder menyimpan order beserta kursinya dalam satu transaction.
+// Total harga selalu dihitung ulang di server, kursi yang sudah terjual untuk schedule yang sama akan menggagalkan seluruh order.
+// userID 0 berarti checkout tamu: id_user dibiarkan NULL, poin tidak bisa dipakai dan batas kursi per schedule dihitung dari email.
+func (r *OrderRepo) CreateOrder(ctx context.Context, req models.OrderRequest, userID int, expiresAt time.Time, pointValue int, limits SeatLimits, accessibleRelease time.Duration) (*models.OrderResponse, error) {
+	if len(req.Seat) > limits.PerOrder {
+		return nil, &SeatLimitError{Scope: models.SeatLimitScopeOrder, ScheduleID: req.ScheduleID, Limit: limits.PerOrder, Requested: len(req.Seat)}
+	}
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// order yang balapan dari pemesan yang sama untuk schedule yang sama dihitung bergantian
+	lockKey := seatLimitLockKey(req.ScheduleID, userID, strings.ToLower(req.Email))
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, lockKey); err != nil {
+		return nil, err
+	}
+	owned, err := ownedSeats(ctx, tx, req.ScheduleID, userID, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if owned+len(req.Seat) > limits.PerUserSchedule {
+		return nil, &SeatLimitError{Scope: models.SeatLimitScopeSchedule, ScheduleID: req.ScheduleID, Limit: limits.PerUserSchedule, Used: owned, Requested: len(req.Seat)}
+	}
+
+	quote, err := r.quote(ctx, tx, req.ScheduleID, req.Seat)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccessibleSeats(ctx, tx, req.ScheduleID, req.Seat, req.Accessible, accessibleRelease); err != nil {
+		return nil, err
+	}
+
+	var promoID int
+	if req.PromoCode != "" {
+		check := promoCheck{Code: req.PromoCode, ScheduleID: req.ScheduleID, PaymentMethodID: req.PaymentMethodID, UserID: userID}
+		if userID == 0 {
+			check.Email = req.Email
+		}
+		promoID, err = applyPromo(ctx, tx, check, quote, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var pointsUsed int
+	if req.Points > 0 {
+		if userID == 0 {
+			return nil, ErrGuestPoints
+		}
+		if err := lockPointBalance(ctx, tx, userID, req.Points); err != nil {
+			return nil, err
+		}
+		pointsUsed = applyPoints(quote, req.Points, pointValue)
+	}
+
+	bookingCode, err := pkg.GenerateBookingCode()
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO orders (ispaid, total_price, qrcode, name, email, phone, id_schedule, id_payment_method, id_user)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, name, email, phone, qrcode;
+		INSERT INTO orders (status, expires_at, total_price, discount, booking_code, qrcode, name, email, phone, id_schedule, id_payment_method, id_user, accessibility)
+		VALUES ($1, $2, $3, $4, $5, '', $6, $7, $8, $9, $10, NULLIF($11::int, 0), $12)
+		RETURNING id, name, email, phone, booking_code, status, expires_at, accessibility;
 	`
 
-	var id int
-	var name, email, phone, qrcode string
-	err := r.DB.QueryRow(ctx, query,
-		req.IsPaid,
-		req.TotalPrice,
-		req.QRCode,
+	res := models.OrderResponse{
+		Subtotal:   quote.Subtotal,
+		Discount:   quote.Discount,
+		TotalPrice: quote.Total,
+		Items:      quote.Items,
+		Classes:    quote.Classes,
+		Promo:      quote.Promo,
+		PointsUsed: pointsUsed,
+	}
+	err = tx.QueryRow(ctx, query,
+		models.OrderStatusPending,
+		expiresAt,
+		quote.Total,
+		quote.Discount,
+		bookingCode,
 		req.Name,
 		req.Email,
 		req.Phone,
 		req.ScheduleID,
 		req.PaymentMethodID,
 		userID,
-	).Scan(&id, &name, &email, &phone, &qrcode)
+		req.Accessible,
+	).Scan(&res.ID, &res.Name, &res.Email, &res.Phone, &res.BookingCode, &res.Status, &res.ExpiresAt, &res.Accessible)
+	if err != nil {
+		return nil, err
+	}
+
+	res.QRCode, err = signTicket(ctx, tx, res.ID, req.ScheduleID, res.BookingCode)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Seat, err = r.createOrderDetails(ctx, tx, res.ID, req.ScheduleID, quote.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// antrean waitlist user untuk schedule ini selesai begitu kursinya dipesan, termasuk kursi dari tawaran waitlist
+	if userID != 0 {
+		if err := closeWaitlist(ctx, tx, req.ScheduleID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if promoID != 0 {
+		if err := recordPromoRedemption(ctx, tx, promoID, res.ID, userID, quote.Promo.Discount); err != nil {
+			return nil, err
+		}
+	}
+	if pointsUsed > 0 {
+		if err := addPoints(ctx, tx, userID, &res.ID, -pointsUsed, PointReasonRedeem); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 
+	return &res, nil
+}
+
+// signTicket menandatangani booking code dan menyimpannya sebagai isi QR code order
+func signTicket(ctx context.Context, db querier, orderID, scheduleID int, bookingCode string) (string, error) {
+	payload := pkg.TicketPayload{OrderID: orderID, ScheduleID: scheduleID, BookingCode: bookingCode}
+	qrcode, err := payload.SignTicket()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := db.Exec(ctx, `UPDATE orders SET qrcode = $1 WHERE id = $2`, qrcode, orderID); err != nil {
+		return "", err
+	}
+	return qrcode, nil
+}
+
+// createOrderDetails insert semua kursi sekaligus. Unique index (id_schedule, id_seat) untuk kursi yang belum dilepas
+// membuat request yang balapan untuk kursi yang sama menunggu transaction lain selesai,
+// lalu kursi yang kalah tidak ikut ter-insert sehingga bisa dilaporkan sebagai konflik.
+func (r *OrderRepo) createOrderDetails(ctx context.Context, tx pgx.Tx, orderID, scheduleID int, items []models.OrderQuoteItem) ([]string, error) {
+	seats := make([]string, 0, len(items))
+	classIDs := make([]int, 0, len(items))
+	prices := make([]int, 0, len(items))
+	for _, item := range items {
+		seats = append(seats, item.Seat)
+		classIDs = append(classIDs, item.SeatClassID)
+		prices = append(prices, item.Price)
+	}
+
+	query := `
+		INSERT INTO orderdetails (id_order, id_schedule, id_seat, id_seat_class, price)
+		SELECT $1, $2, UNNEST($3::varchar[]), UNNEST($4::int[]), UNNEST($5::int[])
+		ON CONFLICT (id_schedule, id_seat) WHERE released_at IS NULL DO NOTHING
+		RETURNING id_seat
+	`
+
+	rows, err := tx.Query(ctx, query, orderID, scheduleID, seats, classIDs, prices)
+	if err != nil {
+		return nil, err
+	}
+	insertedSeats, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(insertedSeats) != len(seats) {
+		var conflicts []string
+		for _, seat := range seats {
+			if !slices.Contains(insertedSeats, seat) {
+				conflicts = append(conflicts, seat)
+			}
+		}
+		return nil, &SeatConflictError{ScheduleID: scheduleID, Seats: conflicts}
+	}
+
+	return insertedSeats, nil
+}
+
+// GetOrderStatus mengambil status order beserta timestamp setiap perpindahan status
+func (r *OrderRepo) GetOrderStatus(ctx context.Context, orderID int) (*models.OrderStatus, error) {
+	return getOrderStatus(ctx, r.DB, orderID)
+}
+
+func getOrderStatus(ctx context.Context, db querier, orderID int) (*models.OrderStatus, error) {
+	query := `
+		SELECT
+			o.id, o.id_user, o.id_schedule, o.status, o.total_price, o.booking_code,
+			o.create_at, o.expires_at, o.awaiting_payment_at, o.paid_at,
+			o.expired_at, o.cancelled_at, o.refunded_at,
+			COALESCE(ARRAY_AGG(od.id_seat ORDER BY od.id_seat) FILTER (WHERE od.id_seat IS NOT NULL), '{}')
+		FROM orders o
+		LEFT JOIN orderdetails od ON od.id_order = o.id
+		WHERE o.id = $1
+		GROUP BY o.id
+	`
+
+	var order models.OrderStatus
+	err := db.QueryRow(ctx, query, orderID).Scan(
+		&order.ID,
+		&order.UserID,
+		&order.ScheduleID,
+		&order.Status,
+		&order.TotalPrice,
+		&order.BookingCode,
+		&order.CreatedAt,
+		&order.ExpiresAt,
+		&order.AwaitingPaymentAt,
+		&order.PaidAt,
+		&order.ExpiredAt,
+		&order.CancelledAt,
+		&order.RefundedAt,
+		&order.Seat,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// GetOrderDetail mengambil detail lengkap satu order beserta rincian harga dan pembayaran terakhirnya
+func (r *OrderRepo) GetOrderDetail(ctx context.Context, orderID int) (*models.OrderDetail, error) {
+	query := `
+		SELECT
+			o.id, o.id_user, o.status, o.status = 'paid', o.booking_code, o.qrcode, o.name, o.email, o.phone,
+			m.id, m.title, COALESCE(m.poster, ''), m.duration, m.rating,
+			s.id, s.date, TO_CHAR(t.time, 'HH24:MI'), c.id, c.name, c.logo, l.name, a.name,
+			COALESCE((SELECT ARRAY_AGG(d.id_seat ORDER BY d.id_seat) FROM orderdetails d WHERE d.id_order = o.id), '{}'),
+			o.accessibility,
+			COALESCE((
+				SELECT JSON_AGG(items ORDER BY items.class, items.unit_price)
+				FROM (
+					SELECT
+						sc.code AS class,
+						sc.name AS class_name,
+						ARRAY_AGG(d.id_seat ORDER BY d.id_seat) AS seats,
+						COUNT(*) AS quantity,
+						d.price AS unit_price,
+						SUM(d.price) AS subtotal
+					FROM orderdetails d
+					JOIN seat_class sc ON sc.id = d.id_seat_class
+					WHERE d.id_order = o.id
+					GROUP BY sc.code, sc.name, d.price
+				) items
+			), '[]'),
+			COALESCE((SELECT SUM(d.price) FROM orderdetails d WHERE d.id_order = o.id), 0),
+			o.discount,
+			COALESCE((SELECT -SUM(pl.points) FROM point_ledger pl WHERE pl.id_order = o.id AND pl.reason = $2), 0),
+			o.total_price, pm.name,
+			o.create_at, o.expires_at, o.awaiting_payment_at, o.paid_at, o.expired_at, o.cancelled_at, o.refunded_at
+		FROM orders o
+		JOIN payment_method pm ON pm.id = o.id_payment_method
+		JOIN schedule s ON s.id = o.id_schedule
+		JOIN movies m ON m.id = s.id_movie
+		JOIN cinema c ON c.id = s.id_cinema
+		JOIN location l ON l.id = s.id_location
+		JOIN time t ON t.id = s.id_time
+		JOIN auditorium a ON a.id = s.id_auditorium
+		WHERE o.id = $1
+	`
+
+	var d models.OrderDetail
+	err := r.DB.QueryRow(ctx, query, orderID, PointReasonRedeem).Scan(
+		&d.ID, &d.UserID, &d.Status, &d.IsPaid, &d.BookingCode, &d.QRCode, &d.Name, &d.Email, &d.Phone,
+		&d.MovieID, &d.MovieTitle, &d.MoviePoster, &d.Duration, &d.Rating,
+		&d.ScheduleID, &d.ShowDate, &d.ShowTime, &d.CinemaID, &d.CinemaName, &d.CinemaLogo, &d.LocationName, &d.Auditorium,
+		&d.Seats,
+		&d.Accessible,
+		&d.Items,
+		&d.Subtotal,
+		&d.Discount,
+		&d.PointsUsed,
+		&d.TotalPrice, &d.PaymentMethod,
+		&d.CreatedAt, &d.ExpiresAt, &d.AwaitingPaymentAt, &d.PaidAt, &d.ExpiredAt, &d.CancelledAt, &d.RefundedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	var promo models.OrderPromo
+	query = `
+		SELECT p.code, p.description, r.discount
+		FROM promo_redemption r
+		JOIN promo_code p ON p.id = r.id_promo
+		WHERE r.id_order = $1
+	`
+	err = r.DB.QueryRow(ctx, query, orderID).Scan(&promo.Code, &promo.Description, &promo.Discount)
+	if err == nil {
+		d.Promo = &promo
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	var p models.Payment
+	query = `
+		SELECT id, id_order, provider, reference, amount, status, payment_url, expires_at, create_at
+		FROM payments
+		WHERE id_order = $1
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	err = r.DB.QueryRow(ctx, query, orderID).Scan(
+		&p.ID, &p.OrderID, &p.Provider, &p.Reference, &p.Amount, &p.Status, &p.PaymentURL, &p.ExpiresAt, &p.CreatedAt,
+	)
+	if err == nil {
+		d.Payment = &p
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// UpdateStatus memindahkan status order sesuai aturan orderTransitions.
+// Order yang lunas mendapat poin sesuai earnRate, kursi, promo dan poin order yang expired dikembalikan.
+// cancelled dan refunded ditolak karena harus lewat CancelOrder yang juga membuat refund dan melepas kursinya.
+func (r *OrderRepo) UpdateStatus(ctx context.Context, orderID int, to string, earnRate int) (*models.OrderStatus, error) {
+	if to == models.OrderStatusCancelled || to == models.OrderStatusRefunded {
+		return nil, ErrStatusNeedsCancel
+	}
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := transitionStatus(ctx, tx, orderID, to); err != nil {
+		return nil, err
+	}
+	switch to {
+	case models.OrderStatusPaid:
+		if err := earnOrderPoints(ctx, tx, orderID, earnRate); err != nil {
+			return nil, err
+		}
+	case models.OrderStatusExpired:
+		if err := releaseOrderSeats(ctx, tx, []int{orderID}); err != nil {
+			return nil, err
+		}
+		if err := releasePromoRedemptions(ctx, tx, []int{orderID}); err != nil {
+			return nil, err
+		}
+		if err := reverseOrderPoints(ctx, tx, orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := getOrderStatus(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// transitionStatus mengunci baris order lalu memindahkan statusnya jika diizinkan, mengembalikan status sebelumnya
+func transitionStatus(ctx context.Context, tx pgx.Tx, orderID int, to string) (string, error) {
+	var from string
+	var expiresAt *time.Time
+	err := tx.QueryRow(ctx, `SELECT status, expires_at FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&from, &expiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrOrderNotFound
+		}
+		return "", err
+	}
+
+	if !slices.Contains(orderTransitions[from], to) {
+		return "", &InvalidTransitionError{OrderID: orderID, From: from, To: to}
+	}
+	if to == models.OrderStatusPaid && expiresAt != nil && time.Now().After(*expiresAt) {
+		return "", ErrPaymentDeadlinePassed
+	}
+
+	query := fmt.Sprintf(`UPDATE orders SET status = $1, %s = NOW(), update_at = NOW() WHERE id = $2`, orderStatusColumns[to])
+	if _, err := tx.Exec(ctx, query, to, orderID); err != nil {
+		return "", err
+	}
+
+	return from, nil
+}
+
+// GetPaymentTarget mengambil data order dan provider dari payment method yang dipilih
+func (r *OrderRepo) GetPaymentTarget(ctx context.Context, orderID int) (*models.OrderPaymentTarget, error) {
+	query := `
+		SELECT o.id, o.id_user, o.status, o.total_price, o.expires_at, o.name, o.email, pm.id, pm.name, pm.provider
+		FROM orders o
+		JOIN payment_method pm ON pm.id = o.id_payment_method
+		WHERE o.id = $1
+	`
+
+	var target models.OrderPaymentTarget
+	err := r.DB.QueryRow(ctx, query, orderID).Scan(
+		&target.OrderID,
+		&target.UserID,
+		&target.Status,
+		&target.TotalPrice,
+		&target.ExpiresAt,
+		&target.Name,
+		&target.Email,
+		&target.PaymentMethodID,
+		&target.PaymentMethod,
+		&target.Provider,
+	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
 		return nil, err
 	}
 
-	return &models.OrderResponse{ID: id, Name: name, Email: email, Phone: phone, QRCode: qrcode, Seat: []string{}}, nil
+	return &target, nil
 }
 
-func (r *OrderRepo) CreateOrderDetails(ctx context.Context, req models.OrderRequest, orderID int) ([]string, error) {
-	query := `INSERT INTO orderdetails (id_order, id_seat) VALUES ($1, $2) RETURNING id_seat`
+// GetActivePayment mengambil charge terakhir order yang masih menunggu dibayar
+func (r *OrderRepo) GetActivePayment(ctx context.Context, orderID int) (*models.Payment, error) {
+	query := `
+		SELECT id, id_order, provider, reference, amount, status, payment_url, expires_at, create_at
+		FROM payments
+		WHERE id_order = $1 AND status = $2 AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY id DESC
+		LIMIT 1
+	`
 
-	var insertedSeats []string
+	var p models.Payment
+	err := r.DB.QueryRow(ctx, query, orderID, payments.ChargeStatusPending).Scan(
+		&p.ID, &p.OrderID, &p.Provider, &p.Reference, &p.Amount, &p.Status, &p.PaymentURL, &p.ExpiresAt, &p.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
 
-	for _, seat := range req.Seat {
-		var seatID string
-		err := r.DB.QueryRow(ctx, query, orderID, seat).Scan(&seatID)
+	return &p, nil
+}
+
+// StartPayment menyimpan charge baru dan memindahkan order ke awaiting_payment
+func (r *OrderRepo) StartPayment(ctx context.Context, payment models.Payment) (*models.Payment, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	if err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, payment.OrderID).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	// order yang sudah awaiting_payment boleh membuat charge baru (misal charge sebelumnya gagal)
+	if status != models.OrderStatusAwaitingPayment {
+		if _, err := transitionStatus(ctx, tx, payment.OrderID, models.OrderStatusAwaitingPayment); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO payments (id_order, provider, reference, amount, status, payment_url, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, create_at
+	`
+	err = tx.QueryRow(ctx, query,
+		payment.OrderID,
+		payment.Provider,
+		payment.Reference,
+		payment.Amount,
+		payment.Status,
+		payment.PaymentURL,
+		payment.ExpiresAt,
+	).Scan(&payment.ID, &payment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// SettlePayment mencatat hasil pembayaran dari provider. Charge yang sukses memindahkan order ke paid dan mengkreditkan poin,
+// event yang sama dikirim ulang tidak mengubah apa-apa. Charge selisih tukar kursi menerapkan atau membatalkan tukar kursinya,
+// hasilnya dikembalikan supaya handler melepas hold kursi baru dan memproses refund jika ada.
+// Charge yang lunas setelah order expired, dibatalkan atau sudah lunas lewat charge lain tetap dicatat paid lalu dibuatkan refund.
+func (r *OrderRepo) SettlePayment(ctx context.Context, reference, status string, amount, earnRate int) (*models.OrderStatus, *SeatChangeSettlement, *models.Refund, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var orderID, paymentAmount int
+	var paymentStatus string
+	query := `SELECT id_order, amount, status FROM payments WHERE reference = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, query, reference).Scan(&orderID, &paymentAmount, &paymentStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil, ErrPaymentNotFound
+		}
+		return nil, nil, nil, err
+	}
+	if paymentAmount != amount {
+		return nil, nil, nil, ErrPaymentAmountMismatch
+	}
+
+	var settlement *SeatChangeSettlement
+	var refund *models.Refund
+	if paymentStatus != status {
+		if _, err := tx.Exec(ctx, `UPDATE payments SET status = $1, update_at = NOW() WHERE reference = $2`, status, reference); err != nil {
+			return nil, nil, nil, err
+		}
+
+		change, err := lockSeatChangePayment(ctx, tx, reference)
 		if err != nil {
+			return nil, nil, nil, err
+		}
+		switch {
+		case change != nil:
+			// charge selisih tukar kursi dibayar saat order sudah lunas, status order tidak berubah
+			if settlement, err = settleSeatChange(ctx, tx, change, status, earnRate); err != nil {
+				return nil, nil, nil, err
+			}
+		case status == payments.ChargeStatusPaid:
+			// transitionStatus menolak sebelum menulis apa pun, jadi tx masih bisa dipakai untuk mencatat refund
+			_, err := transitionStatus(ctx, tx, orderID, models.OrderStatusPaid)
+			var transition *InvalidTransitionError
+			switch {
+			case errors.As(err, &transition), errors.Is(err, ErrPaymentDeadlinePassed):
+				if refund, err = refundLatePayment(ctx, tx, reference); err != nil {
+					return nil, nil, nil, err
+				}
+			case err != nil:
+				return nil, nil, nil, err
+			default:
+				if err := earnOrderPoints(ctx, tx, orderID, earnRate); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+		}
+	}
+
+	order, err := getOrderStatus(ctx, tx, orderID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return order, settlement, refund, nil
+}
+
+// refundLatePayment membuat refund penuh untuk charge yang lunas ketika order sudah tidak bisa dibayar.
+// Refund ini dibuat sistem sehingga tidak ada akun yang tercatat sebagai pembatalnya.
+func refundLatePayment(ctx context.Context, tx pgx.Tx, reference string) (*models.Refund, error) {
+	query := `
+		INSERT INTO refunds (id_order, id_payment, amount, status, reason)
+		SELECT id_order, id, amount, $2, $3
+		FROM payments
+		WHERE reference = $1
+		RETURNING id, id_order, id_payment, amount, status, reason, create_at
+	`
+	var refund models.Refund
+	err := tx.QueryRow(ctx, query, reference, payments.ChargeStatusPending, "payment received after the order could no longer be paid").Scan(
+		&refund.ID, &refund.OrderID, &refund.PaymentID, &refund.Amount, &refund.Status, &refund.Reason, &refund.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(ctx, `SELECT provider, reference FROM payments WHERE id = $1`, *refund.PaymentID).Scan(&refund.Provider, &refund.PaymentReference); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// releaseOrderSeats melepas kursi order supaya bisa dibeli lagi, baris orderdetails tetap disimpan untuk riwayat
+func releaseOrderSeats(ctx context.Context, db querier, orderIDs []int) error {
+	query := `UPDATE orderdetails SET released_at = NOW() WHERE id_order = ANY($1::int[]) AND released_at IS NULL`
+	_, err := db.Exec(ctx, query, orderIDs)
+	return err
+}
+
+// ExpireOverdueOrders menandai order yang belum dibayar melewati expires_at sebagai expired lalu melepas kursi, kuota promo dan poinnya.
+// SKIP LOCKED membuat beberapa instance bisa berjalan bersamaan tanpa memproses order yang sama.
+func (r *OrderRepo) ExpireOverdueOrders(ctx context.Context, limit int) ([]models.ExpiredOrder, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE orders
+		SET status = $1, expired_at = NOW(), update_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM orders
+			WHERE status = ANY($2::varchar[]) AND expires_at < NOW()
+			ORDER BY expires_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, id_user, id_schedule
+	`
+
+	rows, err := tx.Query(ctx, query,
+		models.OrderStatusExpired,
+		[]string{models.OrderStatusPending, models.OrderStatusAwaitingPayment},
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	expired, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ExpiredOrder, error) {
+		var o models.ExpiredOrder
+		err := row.Scan(&o.ID, &o.UserID, &o.ScheduleID)
+		return o, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	orderIDs := make([]int, 0, len(expired))
+	for _, o := range expired {
+		orderIDs = append(orderIDs, o.ID)
+	}
+
+	if err := releaseOrderSeats(ctx, tx, orderIDs); err != nil {
+		return nil, err
+	}
+	if err := releasePromoRedemptions(ctx, tx, orderIDs); err != nil {
+		return nil, err
+	}
+	for _, id := range orderIDs {
+		if err := reverseOrderPoints(ctx, tx, id); err != nil {
 			return nil, err
 		}
-		insertedSeats = append(insertedSeats, seatID)
 	}
 
-	return insertedSeats, nil
+	query = `UPDATE payments SET status = $1, update_at = NOW() WHERE id_order = ANY($2::int[]) AND status = $3`
+	if _, err := tx.Exec(ctx, query, payments.ChargeStatusExpired, orderIDs, payments.ChargeStatusPending); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
+
+// CancelOrder membatalkan order, melepas kursi, membatalkan charge yang masih pending, dan mengembalikan poin.
+// Order yang sudah dibayar mendapat data refund pending yang diproses ke provider oleh handler.
+// cutoff 0 berarti tanpa batas waktu dan tiket hasil transfer tetap bisa dibatalkan (dipakai admin).
+func (r *OrderRepo) CancelOrder(ctx context.Context, orderID, cancelledBy int, reason string, cutoff time.Duration) (*models.OrderCancellation, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// tiket yang sudah dipakai masuk studio tidak bisa dibatalkan, termasuk oleh admin
+	var checkedIn bool
+	if err := tx.QueryRow(ctx, `SELECT checkin_at IS NOT NULL FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&checkedIn); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if checkedIn {
+		return nil, ErrOrderCheckedIn
+	}
+
+	if cutoff > 0 {
+		query := `
+			SELECT LOCALTIMESTAMP + make_interval(secs => $2) > s.date + t.time
+			FROM orders o
+			JOIN schedule s ON s.id = o.id_schedule
+			JOIN time t ON t.id = s.id_time
+			WHERE o.id = $1;
+		`
+		var passed bool
+		if err := tx.QueryRow(ctx, query, orderID, cutoff.Seconds()).Scan(&passed); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, err
+		}
+		if passed {
+			return nil, ErrCancelCutoffPassed
+		}
+
+		// hanya pembatalan oleh user yang dibatasi, admin tetap bisa membatalkan tiket hasil transfer
+		transferred, err := isTransferred(ctx, tx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if transferred {
+			return nil, ErrOrderTransferred
+		}
+	}
+
+	from, err := transitionStatus(ctx, tx, orderID, models.OrderStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := releaseOrderSeats(ctx, tx, []int{orderID}); err != nil {
+		return nil, err
+	}
+	if err := releasePromoRedemptions(ctx, tx, []int{orderID}); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE payments SET status = $1, update_at = NOW() WHERE id_order = $2 AND status = $3`
+	if _, err := tx.Exec(ctx, query, payments.ChargeStatusExpired, orderID, payments.ChargeStatusPending); err != nil {
+		return nil, err
+	}
+	// hold kursi tukar kursi yang belum dibayar dibiarkan habis sendiri pada expires_at-nya
+	query = `UPDATE seat_change SET status = $1, update_at = NOW() WHERE id_order = $2 AND status = $3`
+	if _, err := tx.Exec(ctx, query, models.SeatChangeStatusExpired, orderID, models.SeatChangeStatusPending); err != nil {
+		return nil, err
+	}
+
+	if err := reverseOrderPoints(ctx, tx, orderID); err != nil {
+		return nil, err
+	}
+
+	var refund *models.Refund
+	if from == models.OrderStatusPaid {
+		refund, err = createRefund(ctx, tx, orderID, cancelledBy, 0, reason)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := getOrderStatus(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &models.OrderCancellation{Order: *order, Refund: refund}, nil
+}
+
+// createRefund mencatat refund sebesar amount, atau total order jika amount 0, dikaitkan ke charge order yang sudah dibayar jika ada
+func createRefund(ctx context.Context, tx pgx.Tx, orderID, cancelledBy, amount int, reason string) (*models.Refund, error) {
+	query := `
+		INSERT INTO refunds (id_order, id_payment, amount, status, reason, cancelled_by)
+		SELECT o.id, p.id, COALESCE(NULLIF($6::int, 0), o.total_price), $2, NULLIF($3, ''), $4
+		FROM orders o
+		LEFT JOIN LATERAL (
+			SELECT id
+			FROM payments
+			WHERE id_order = o.id AND status = $5
+				AND NOT EXISTS (SELECT 1 FROM seat_change sc WHERE sc.id_payment = payments.id)
+			ORDER BY id DESC
+			LIMIT 1
+		) p ON true
+		WHERE o.id = $1
+		RETURNING id, id_order, id_payment, amount, status, reason, create_at;
+	`
+
+	var refund models.Refund
+	err := tx.QueryRow(ctx, query, orderID, payments.ChargeStatusPending, reason, cancelledBy, payments.ChargeStatusPaid, amount).Scan(
+		&refund.ID, &refund.OrderID, &refund.PaymentID, &refund.Amount, &refund.Status, &refund.Reason, &refund.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.PaymentID != nil {
+		err := tx.QueryRow(ctx, `SELECT provider, reference FROM payments WHERE id = $1`, *refund.PaymentID).Scan(&refund.Provider, &refund.PaymentReference)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &refund, nil
+}
+
+// CompleteRefund mencatat hasil refund dari provider. Refund pembatalan yang sukses memindahkan order ke refunded.
+func (r *OrderRepo) CompleteRefund(ctx context.Context, refundID int, status string, reference *string) (*models.OrderCancellation, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE refunds SET status = $1, reference = $2, update_at = NOW()
+		WHERE id = $3
+		RETURNING id, id_order, id_payment, amount, status, reference, reason, create_at;
+	`
+
+	var refund models.Refund
+	err = tx.QueryRow(ctx, query, status, reference, refundID).Scan(
+		&refund.ID, &refund.OrderID, &refund.PaymentID, &refund.Amount, &refund.Status, &refund.Reference, &refund.Reason, &refund.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRefundNotFound
+		}
+		return nil, err
+	}
+
+	// refund selisih tukar kursi terjadi pada order yang masih lunas, order dan charge-nya tidak ikut refunded
+	var orderStatus string
+	if err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, refund.OrderID).Scan(&orderStatus); err != nil {
+		return nil, err
+	}
+	fullRefund := status == payments.ChargeStatusRefunded && orderStatus == models.OrderStatusCancelled
+
+	if refund.PaymentID != nil {
+		// refund tukar kursi yang batal diterapkan mengembalikan seluruh charge selisihnya, charge itu ikut refunded
+		query := `
+			UPDATE payments SET status = CASE WHEN $3 AND amount <= $4 THEN $5 ELSE $1 END, update_at = NOW()
+			WHERE id = $2
+			RETURNING provider, reference
+		`
+		paymentStatus := payments.ChargeStatusPaid
+		if fullRefund {
+			paymentStatus = payments.ChargeStatusRefunded
+		}
+		err := tx.QueryRow(ctx, query, paymentStatus, *refund.PaymentID, status == payments.ChargeStatusRefunded, refund.Amount, payments.ChargeStatusRefunded).
+			Scan(&refund.Provider, &refund.PaymentReference)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if fullRefund {
+		if _, err := transitionStatus(ctx, tx, refund.OrderID, models.OrderStatusRefunded); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := getOrderStatus(ctx, tx, refund.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &models.OrderCancellation{Order: *order, Refund: &refund}, nil
+}
+
+// GetTicket mengambil booking code dan isi QR code order
+func (r *OrderRepo) GetTicket(ctx context.Context, orderID int) (*models.Ticket, error) {
+	query := `SELECT id, id_user, id_schedule, status, booking_code, qrcode FROM orders WHERE id = $1`
+
+	var t models.Ticket
+	err := r.DB.QueryRow(ctx, query, orderID).Scan(&t.OrderID, &t.UserID, &t.ScheduleID, &t.Status, &t.BookingCode, &t.QRCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// LookupOrder mencari order lewat email pemesan dan booking code, dipakai tamu untuk mengambil tiketnya
+func (r *OrderRepo) LookupOrder(ctx context.Context, email, bookingCode string) (*models.OrderHistory, error) {
+	query := fmt.Sprintf(orderHistoryQuery, "LOWER(o.email) = LOWER($1) AND UPPER(o.booking_code) = UPPER($2)", "o.id DESC")
+	rows, err := r.DB.Query(ctx, query, strings.TrimSpace(email), strings.TrimSpace(bookingCode))
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := collectOrderHistory(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, ErrOrderNotFound
+	}
+	return &orders[0], nil
+}
+
+// GetOrderConflicts kursi terjual ganda yang dilepas migrasi unique kursi per schedule, terbaru lebih dulu
+func (r *OrderRepo) GetOrderConflicts(ctx context.Context) ([]models.OrderConflict, error) {
+	query := `
+		SELECT c.id_order, o.email, o.status, c.id_schedule, c.id_seat, c.kept_order, c.flagged_at
+		FROM orderdetails_conflict c
+		JOIN orders o ON o.id = c.id_order
+		ORDER BY c.flagged_at DESC, c.id_order, c.id_seat
+	`
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.OrderConflict])
 }
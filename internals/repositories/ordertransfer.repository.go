@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/pkg"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrTransferNotFound          = errors.New("transfer not found")
+	ErrTransferRecipientNotFound = errors.New("no user account is registered with this email")
+	ErrTransferSelf              = errors.New("tickets cannot be transferred to your own account")
+	ErrTransferOrderStatus       = errors.New("only paid tickets that have not been used can be transferred")
+	ErrTransferCutoffPassed      = errors.New("tickets can no longer be transferred this close to the showtime")
+	ErrTransferPending           = errors.New("order already has a transfer waiting for the recipient")
+	ErrTransferNotPending        = errors.New("transfer has already been answered")
+	ErrOrderTransferred          = errors.New("transferred tickets cannot be cancelled or changed")
+)
+
+// orderTransferQuery data transfer beserta ringkasan tiketnya, %s diisi kondisi WHERE
+const orderTransferQuery = `
+	SELECT
+		ot.id, ot.id_order, ot.from_user, fa.email, ot.to_user, ta.email, ot.status,
+		m.title, c.name, s.date, TO_CHAR(t.time, 'HH24:MI'),
+		COALESCE((SELECT ARRAY_AGG(d.id_seat ORDER BY d.id_seat) FROM orderdetails d WHERE d.id_order = ot.id_order AND d.released_at IS NULL), '{}'),
+		ot.create_at, ot.responded_at
+	FROM order_transfer ot
+	JOIN account fa ON fa.id = ot.from_user
+	JOIN account ta ON ta.id = ot.to_user
+	JOIN orders o ON o.id = ot.id_order
+	JOIN schedule s ON s.id = o.id_schedule
+	JOIN movies m ON m.id = s.id_movie
+	JOIN cinema c ON c.id = s.id_cinema
+	JOIN time t ON t.id = s.id_time
+`
+
+func scanOrderTransfer(row pgx.Row) (*models.OrderTransfer, error) {
+	var t models.OrderTransfer
+	err := row.Scan(
+		&t.ID, &t.OrderID, &t.FromUserID, &t.FromEmail, &t.ToUserID, &t.ToEmail, &t.Status,
+		&t.MovieTitle, &t.CinemaName, &t.ShowDate, &t.ShowTime,
+		&t.Seat,
+		&t.CreatedAt, &t.RespondedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func getOrderTransfer(ctx context.Context, db querier, transferID int) (*models.OrderTransfer, error) {
+	return scanOrderTransfer(db.QueryRow(ctx, orderTransferQuery+` WHERE ot.id = $1`, transferID))
+}
+
+// isTransferred true jika order pernah diterima dari akun lain. Refund order mengalir ke pembayar asli,
+// jadi penerima tidak boleh membatalkan atau menukar kursinya.
+func isTransferred(ctx context.Context, db querier, orderID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM order_transfer WHERE id_order = $1 AND status = $2)`
+	var transferred bool
+	err := db.QueryRow(ctx, query, orderID, models.TransferStatusAccepted).Scan(&transferred)
+	return transferred, err
+}
+
+// lockTransferableOrder mengunci order lalu memastikan order masih milik userID, sudah lunas, belum dipakai dan belum lewat cutoff
+func lockTransferableOrder(ctx context.Context, tx pgx.Tx, orderID, userID int, cutoff time.Duration) (scheduleID int, err error) {
+	query := `
+		SELECT o.id_user, o.id_schedule, o.status, o.checkin_at IS NOT NULL, LOCALTIMESTAMP + make_interval(secs => $2) > s.date + t.time
+		FROM orders o
+		JOIN schedule s ON s.id = o.id_schedule
+		JOIN time t ON t.id = s.id_time
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`
+
+	var ownerID *int
+	var status string
+	var used, passed bool
+	if err := tx.QueryRow(ctx, query, orderID, cutoff.Seconds()).Scan(&ownerID, &scheduleID, &status, &used, &passed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrOrderNotFound
+		}
+		return 0, err
+	}
+	if ownerID == nil || *ownerID != userID || status != models.OrderStatusPaid || used {
+		return 0, ErrTransferOrderStatus
+	}
+	if passed {
+		return 0, ErrTransferCutoffPassed
+	}
+	return scheduleID, nil
+}
+
+// CreateTransfer membuat permintaan transfer order milik fromUser ke akun user dengan email tersebut.
+// Order baru berpindah setelah penerima menerima transfer.
+func (r *OrderRepo) CreateTransfer(ctx context.Context, orderID, fromUser int, email string, cutoff time.Duration) (*models.OrderTransfer, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockTransferableOrder(ctx, tx, orderID, fromUser, cutoff); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT a.id
+		FROM account a
+		JOIN users u ON u.id = a.id
+		WHERE LOWER(a.email) = LOWER($1) AND a.role = 'user'
+	`
+	var toUser int
+	if err := tx.QueryRow(ctx, query, email).Scan(&toUser); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferRecipientNotFound
+		}
+		return nil, err
+	}
+	if toUser == fromUser {
+		return nil, ErrTransferSelf
+	}
+
+	var transferID int
+	query = `INSERT INTO order_transfer (id_order, from_user, to_user, status) VALUES ($1, $2, $3, $4) RETURNING id`
+	if err := tx.QueryRow(ctx, query, orderID, fromUser, toUser, models.TransferStatusPending).Scan(&transferID); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrTransferPending
+		}
+		return nil, err
+	}
+
+	transfer, err := getOrderTransfer(ctx, tx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetPendingTransfers transfer yang masih menunggu jawaban, baik yang dikirim maupun yang diterima user
+func (r *OrderRepo) GetPendingTransfers(ctx context.Context, userID int) ([]models.OrderTransfer, error) {
+	query := orderTransferQuery + ` WHERE (ot.from_user = $1 OR ot.to_user = $1) AND ot.status = $2 ORDER BY ot.id DESC`
+	rows, err := r.DB.Query(ctx, query, userID, models.TransferStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []models.OrderTransfer{}
+	for rows.Next() {
+		t, err := scanOrderTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *t)
+	}
+	return transfers, rows.Err()
+}
+
+// lockPendingTransfer mengunci transfer pending milik userID. Transfer milik akun lain dilaporkan tidak ditemukan.
+// recipient menentukan userID dicocokkan sebagai penerima atau pengirim.
+func lockPendingTransfer(ctx context.Context, tx pgx.Tx, transferID, userID int, recipient bool) (orderID, fromUser, toUser int, err error) {
+	query := `SELECT id_order, from_user, to_user, status FROM order_transfer WHERE id = $1 FOR UPDATE`
+
+	var status string
+	if err := tx.QueryRow(ctx, query, transferID).Scan(&orderID, &fromUser, &toUser, &status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, 0, ErrTransferNotFound
+		}
+		return 0, 0, 0, err
+	}
+	if (recipient && toUser != userID) || (!recipient && fromUser != userID) {
+		return 0, 0, 0, ErrTransferNotFound
+	}
+	if status != models.TransferStatusPending {
+		return 0, 0, 0, ErrTransferNotPending
+	}
+	return orderID, fromUser, toUser, nil
+}
+
+// AcceptTransfer memindahkan order ke penerima. Booking code dan QR lama diganti supaya tidak bisa dipakai lagi,
+// data pemesan ikut diganti dengan data penerima, dan kursi order dihitung ke batas kursi penerima untuk schedule tersebut.
+func (r *OrderRepo) AcceptTransfer(ctx context.Context, transferID, userID int, cutoff time.Duration, perUserSchedule int) (*models.OrderTransfer, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	orderID, fromUser, _, err := lockPendingTransfer(ctx, tx, transferID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	scheduleID, err := lockTransferableOrder(ctx, tx, orderID, fromUser, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := ownedSeats(ctx, tx, scheduleID, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	var seats int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM orderdetails WHERE id_order = $1 AND released_at IS NULL`, orderID).Scan(&seats); err != nil {
+		return nil, err
+	}
+	if owned+seats > perUserSchedule {
+		return nil, &SeatLimitError{Scope: models.SeatLimitScopeSchedule, ScheduleID: scheduleID, Limit: perUserSchedule, Used: owned, Requested: seats}
+	}
+
+	bookingCode, err := pkg.GenerateBookingCode()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE orders o SET
+			id_user = a.id,
+			booking_code = $3,
+			name = COALESCE(NULLIF(TRIM(CONCAT_WS(' ', u.firstname, u.lastname)), ''), a.email),
+			email = a.email,
+			phone = COALESCE(u.phone, ''),
+			update_at = NOW()
+		FROM account a
+		JOIN users u ON u.id = a.id
+		WHERE o.id = $1 AND a.id = $2
+	`
+	if _, err := tx.Exec(ctx, query, orderID, userID, bookingCode); err != nil {
+		return nil, err
+	}
+	if _, err := signTicket(ctx, tx, orderID, scheduleID, bookingCode); err != nil {
+		return nil, err
+	}
+
+	query = `UPDATE order_transfer SET status = $1, responded_at = NOW() WHERE id = $2`
+	if _, err := tx.Exec(ctx, query, models.TransferStatusAccepted, transferID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := getOrderTransfer(ctx, tx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	transfer.BookingCode = &bookingCode
+	return transfer, nil
+}
+
+// CloseTransfer menutup transfer pending tanpa memindahkan order: declined oleh penerima, cancelled oleh pengirim
+func (r *OrderRepo) CloseTransfer(ctx context.Context, transferID, userID int, status string) (*models.OrderTransfer, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, _, _, err := lockPendingTransfer(ctx, tx, transferID, userID, status == models.TransferStatusDeclined); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE order_transfer SET status = $1, responded_at = NOW() WHERE id = $2`
+	if _, err := tx.Exec(ctx, query, status, transferID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := getOrderTransfer(ctx, tx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
@@ -17,7 +17,7 @@ func NewPaymentMethodRepository(db *pgxpool.Pool) *PaymentMethodRepository {
 }
 
 func (r *PaymentMethodRepository) GetAll(ctx context.Context) ([]models.PaymentMethod, error) {
-	query := `SELECT id, name, logo FROM payment_method`
+	query := `SELECT id, name, logo, provider, bank_code FROM payment_method`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		log.Println("Failed to fetch payment methods:", err)
@@ -28,7 +28,7 @@ func (r *PaymentMethodRepository) GetAll(ctx context.Context) ([]models.PaymentM
 	var methods []models.PaymentMethod
 	for rows.Next() {
 		var pm models.PaymentMethod
-		if err := rows.Scan(&pm.ID, &pm.Name, &pm.Logo); err != nil {
+		if err := rows.Scan(&pm.ID, &pm.Name, &pm.Logo, &pm.Provider, &pm.BankCode); err != nil {
 			log.Println("Failed to scan payment method:", err)
 			return nil, err
 		}
@@ -0,0 +1,396 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPricingRuleNotFound = errors.New("pricing rule not found")
+	ErrInvalidPricingRule  = errors.New("invalid pricing rule")
+	ErrHolidayNotFound     = errors.New("holiday not found")
+	ErrHolidayExists       = errors.New("holiday date already registered")
+)
+
+const pricingDateLayout = "2006-01-02"
+
+const pricingRuleColumns = `
+	id, name, id_cinema, days::int[], start_time::text, end_time::text, holiday,
+	to_char(start_date, 'YYYY-MM-DD'), to_char(end_date, 'YYYY-MM-DD'),
+	price, modifier, priority, active
+`
+
+type PricingRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPricingRepo(db *pgxpool.Pool) *PricingRepo {
+	return &PricingRepo{DB: db}
+}
+
+// pricingSlot data schedule yang dipakai untuk mencocokkan pricing rule
+type pricingSlot struct {
+	CinemaID    int
+	Date        time.Time
+	Time        string
+	CinemaPrice int
+}
+
+// pricingEngine menyimpan rule aktif yang sudah diurutkan sesuai prioritas beserta kalender libur
+type pricingEngine struct {
+	rules    []models.PricingRule
+	holidays map[string]string
+}
+
+// ruleSpecificity jumlah kondisi yang diisi, rule yang lebih spesifik menang jika priority sama
+func ruleSpecificity(rule models.PricingRule) int {
+	n := 0
+	if rule.CinemaID != nil {
+		n++
+	}
+	if len(rule.Days) > 0 {
+		n++
+	}
+	if rule.StartTime != nil {
+		n++
+	}
+	if rule.Holiday != nil {
+		n++
+	}
+	if rule.StartDate != nil || rule.EndDate != nil {
+		n++
+	}
+	return n
+}
+
+// isoWeekday hari dalam format ISO, 1 = Senin ... 7 = Minggu
+func isoWeekday(date time.Time) int {
+	if date.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(date.Weekday())
+}
+
+// sortPricingRules mengurutkan rule dari yang paling didahulukan: priority tertinggi, lalu yang paling spesifik, lalu rule terbaru
+func sortPricingRules(rules []models.PricingRule) {
+	slices.SortStableFunc(rules, func(a, b models.PricingRule) int {
+		if a.Priority != b.Priority {
+			return b.Priority - a.Priority
+		}
+		if sa, sb := ruleSpecificity(a), ruleSpecificity(b); sa != sb {
+			return sb - sa
+		}
+		return b.ID - a.ID
+	})
+}
+
+func loadPricingEngine(ctx context.Context, db querier) (*pricingEngine, error) {
+	rows, err := db.Query(ctx, `SELECT `+pricingRuleColumns+` FROM pricing_rule WHERE active AND delete_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := pgx.CollectRows(rows, pgx.RowToStructByPos[models.PricingRule])
+	if err != nil {
+		return nil, err
+	}
+	sortPricingRules(rules)
+
+	rows, err = db.Query(ctx, `SELECT id, to_char(date, 'YYYY-MM-DD'), name FROM holiday`)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := pgx.CollectRows(rows, pgx.RowToStructByPos[models.Holiday])
+	if err != nil {
+		return nil, err
+	}
+
+	engine := &pricingEngine{rules: rules, holidays: make(map[string]string, len(holidays))}
+	for _, h := range holidays {
+		engine.holidays[h.Date] = h.Name
+	}
+	return engine, nil
+}
+
+// mismatches mengembalikan kondisi rule yang tidak terpenuhi oleh schedule, kosong berarti rule cocok
+func (e *pricingEngine) mismatches(rule models.PricingRule, slot pricingSlot) []string {
+	var reasons []string
+	date := slot.Date.Format(pricingDateLayout)
+
+	if rule.CinemaID != nil && *rule.CinemaID != slot.CinemaID {
+		reasons = append(reasons, fmt.Sprintf("only for cinema %d", *rule.CinemaID))
+	}
+	if len(rule.Days) > 0 && !slices.Contains(rule.Days, isoWeekday(slot.Date)) {
+		reasons = append(reasons, fmt.Sprintf("only on days %v, schedule is on day %d", rule.Days, isoWeekday(slot.Date)))
+	}
+	if rule.StartTime != nil && rule.EndTime != nil {
+		start, end := *rule.StartTime, *rule.EndTime
+		inRange := slot.Time >= start && slot.Time <= end
+		if start > end {
+			inRange = slot.Time >= start || slot.Time <= end
+		}
+		if !inRange {
+			reasons = append(reasons, fmt.Sprintf("only between %s and %s", start, end))
+		}
+	}
+	if rule.Holiday != nil {
+		_, isHoliday := e.holidays[date]
+		if *rule.Holiday && !isHoliday {
+			reasons = append(reasons, "only on holidays")
+		}
+		if !*rule.Holiday && isHoliday {
+			reasons = append(reasons, "not on holidays")
+		}
+	}
+	if rule.StartDate != nil && date < *rule.StartDate {
+		reasons = append(reasons, fmt.Sprintf("starts on %s", *rule.StartDate))
+	}
+	if rule.EndDate != nil && date > *rule.EndDate {
+		reasons = append(reasons, fmt.Sprintf("ended on %s", *rule.EndDate))
+	}
+	return reasons
+}
+
+// rulePrice harga dasar jika rule dipakai, tidak pernah kurang dari 0
+func rulePrice(rule models.PricingRule, cinemaPrice int) int {
+	if rule.Price != nil {
+		return *rule.Price
+	}
+	return max(cinemaPrice+rule.Modifier, 0)
+}
+
+// resolve memilih rule pertama yang cocok, tanpa rule yang cocok harga dasar = cinema.price
+func (e *pricingEngine) resolve(slot pricingSlot) (int, *models.PricingRule) {
+	for i := range e.rules {
+		if len(e.mismatches(e.rules[i], slot)) == 0 {
+			return rulePrice(e.rules[i], slot.CinemaPrice), &e.rules[i]
+		}
+	}
+	return slot.CinemaPrice, nil
+}
+
+// scheduleSlot mengambil data schedule yang dibutuhkan pricing engine
+func scheduleSlot(ctx context.Context, db querier, scheduleID int) (*pricingSlot, error) {
+	query := `
+		SELECT s.id_cinema, s.date, t.time::text, c.price
+		FROM schedule s
+		JOIN cinema c ON c.id = s.id_cinema
+		JOIN time t ON t.id = s.id_time
+		WHERE s.id = $1 AND s.delete_at IS NULL;
+	`
+
+	var slot pricingSlot
+	if err := db.QueryRow(ctx, query, scheduleID).Scan(&slot.CinemaID, &slot.Date, &slot.Time, &slot.CinemaPrice); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// schedulePrice harga dasar schedule dari pricing engine, dipakai untuk harga kursi dan checkout
+func schedulePrice(ctx context.Context, db querier, scheduleID int) (int, error) {
+	slot, err := scheduleSlot(ctx, db, scheduleID)
+	if err != nil {
+		return 0, err
+	}
+	engine, err := loadPricingEngine(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	price, _ := engine.resolve(*slot)
+	return price, nil
+}
+
+func (r *PricingRepo) GetRules(ctx context.Context) ([]models.PricingRule, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+pricingRuleColumns+` FROM pricing_rule WHERE delete_at IS NULL ORDER BY priority DESC, id`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.PricingRule])
+}
+
+// validatePricingRule cek kombinasi kondisi yang tidak bisa dicek lewat binding
+func validatePricingRule(req models.PricingRuleRequest) error {
+	if (req.StartTime == nil) != (req.EndTime == nil) {
+		return fmt.Errorf("%w: start_time and end_time must be filled together", ErrInvalidPricingRule)
+	}
+	if req.StartDate != nil && req.EndDate != nil && *req.StartDate > *req.EndDate {
+		return fmt.Errorf("%w: start_date must not be after end_date", ErrInvalidPricingRule)
+	}
+	return nil
+}
+
+// isForeignKeyViolation cek error foreign key constraint dari postgres
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+func scanPricingRule(row pgx.Row) (*models.PricingRule, error) {
+	var rule models.PricingRule
+	err := row.Scan(
+		&rule.ID, &rule.Name, &rule.CinemaID, &rule.Days, &rule.StartTime, &rule.EndTime, &rule.Holiday,
+		&rule.StartDate, &rule.EndDate, &rule.Price, &rule.Modifier, &rule.Priority, &rule.Active,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPricingRuleNotFound
+		}
+		if isForeignKeyViolation(err) {
+			return nil, ErrCinemaNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *PricingRepo) CreateRule(ctx context.Context, req models.PricingRuleRequest) (*models.PricingRule, error) {
+	if err := validatePricingRule(req); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO pricing_rule (name, id_cinema, days, start_time, end_time, holiday, start_date, end_date, price, modifier, priority, active)
+		VALUES ($1, $2, $3::smallint[], $4::time, $5::time, $6, $7::date, $8::date, $9, $10, $11, COALESCE($12, TRUE))
+		RETURNING ` + pricingRuleColumns
+
+	return scanPricingRule(r.DB.QueryRow(ctx, query,
+		req.Name, req.CinemaID, req.Days, req.StartTime, req.EndTime, req.Holiday,
+		req.StartDate, req.EndDate, req.Price, req.Modifier, req.Priority, req.Active,
+	))
+}
+
+func (r *PricingRepo) UpdateRule(ctx context.Context, id int, req models.PricingRuleRequest) (*models.PricingRule, error) {
+	if err := validatePricingRule(req); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE pricing_rule
+		SET name = $1, id_cinema = $2, days = $3::smallint[], start_time = $4::time, end_time = $5::time, holiday = $6,
+			start_date = $7::date, end_date = $8::date, price = $9, modifier = $10, priority = $11,
+			active = COALESCE($12, active), update_at = NOW()
+		WHERE id = $13 AND delete_at IS NULL
+		RETURNING ` + pricingRuleColumns
+
+	return scanPricingRule(r.DB.QueryRow(ctx, query,
+		req.Name, req.CinemaID, req.Days, req.StartTime, req.EndTime, req.Holiday,
+		req.StartDate, req.EndDate, req.Price, req.Modifier, req.Priority, req.Active, id,
+	))
+}
+
+// DeleteRule soft delete, order yang sudah dibuat tetap memakai harga saat dibeli (orderdetails.price)
+func (r *PricingRepo) DeleteRule(ctx context.Context, id int) error {
+	tag, err := r.DB.Exec(ctx, `UPDATE pricing_rule SET delete_at = NOW() WHERE id = $1 AND delete_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPricingRuleNotFound
+	}
+	return nil
+}
+
+func (r *PricingRepo) GetHolidays(ctx context.Context) ([]models.Holiday, error) {
+	rows, err := r.DB.Query(ctx, `SELECT id, to_char(date, 'YYYY-MM-DD'), name FROM holiday ORDER BY date`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.Holiday])
+}
+
+func (r *PricingRepo) CreateHoliday(ctx context.Context, req models.HolidayRequest) (*models.Holiday, error) {
+	var h models.Holiday
+	err := r.DB.QueryRow(ctx, `
+		INSERT INTO holiday (date, name) VALUES ($1::date, $2)
+		RETURNING id, to_char(date, 'YYYY-MM-DD'), name;
+	`, req.Date, req.Name).Scan(&h.ID, &h.Date, &h.Name)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrHolidayExists
+		}
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *PricingRepo) DeleteHoliday(ctx context.Context, id int) error {
+	tag, err := r.DB.Exec(ctx, `DELETE FROM holiday WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHolidayNotFound
+	}
+	return nil
+}
+
+// Preview menjelaskan harga schedule: semua rule aktif dievaluasi sesuai urutan prioritas,
+// rule pertama yang cocok dipakai sebagai harga dasar sebelum modifier kelas kursi.
+func (r *PricingRepo) Preview(ctx context.Context, scheduleID int) (*models.PricingPreview, error) {
+	slot, err := scheduleSlot(ctx, r.DB, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := loadPricingEngine(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	res := models.PricingPreview{
+		ScheduleID:  scheduleID,
+		CinemaID:    slot.CinemaID,
+		Date:        slot.Date.Format(pricingDateLayout),
+		Time:        slot.Time,
+		DayOfWeek:   isoWeekday(slot.Date),
+		CinemaPrice: slot.CinemaPrice,
+	}
+	if err := r.DB.QueryRow(ctx, `SELECT name FROM cinema WHERE id = $1`, slot.CinemaID).Scan(&res.Cinema); err != nil {
+		return nil, err
+	}
+	if name, ok := engine.holidays[res.Date]; ok {
+		res.Holiday = &name
+	}
+
+	res.Price, res.AppliedRule = engine.resolve(*slot)
+	res.Rules = make([]models.PricingRuleMatch, 0, len(engine.rules))
+	for _, rule := range engine.rules {
+		reasons := engine.mismatches(rule, *slot)
+		res.Rules = append(res.Rules, models.PricingRuleMatch{
+			Rule:        rule,
+			Matched:     len(reasons) == 0,
+			Applied:     res.AppliedRule != nil && res.AppliedRule.ID == rule.ID,
+			Specificity: ruleSpecificity(rule),
+			Reasons:     reasons,
+		})
+	}
+
+	switch {
+	case res.AppliedRule == nil:
+		res.Explanation = fmt.Sprintf("no rule matched: cinema price %d", slot.CinemaPrice)
+	case res.AppliedRule.Price != nil:
+		res.Explanation = fmt.Sprintf("rule #%d %s applied: fixed price %d", res.AppliedRule.ID, res.AppliedRule.Name, res.Price)
+	default:
+		res.Explanation = fmt.Sprintf("rule #%d %s applied: cinema price %d %+d", res.AppliedRule.ID, res.AppliedRule.Name, slot.CinemaPrice, res.AppliedRule.Modifier)
+	}
+
+	classes, err := r.DB.Query(ctx, `SELECT code, name, COALESCE(price, $1 + modifier) FROM seat_class ORDER BY id`, res.Price)
+	if err != nil {
+		return nil, err
+	}
+	res.Classes, err = pgx.CollectRows(classes, pgx.RowToStructByPos[models.PricingClassPrice])
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
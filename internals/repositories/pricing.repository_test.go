@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// testSlot schedule Sabtu 17 Oktober 2026 di cinema 1 dengan harga dasar 50
+func testSlot(showTime string) pricingSlot {
+	return pricingSlot{CinemaID: 1, Date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Time: showTime, CinemaPrice: 50}
+}
+
+func TestPricingEngineMismatches(t *testing.T) {
+	overnight := models.PricingRule{StartTime: ptr("22:00:00"), EndTime: ptr("02:00:00")}
+	daytime := models.PricingRule{StartTime: ptr("10:00:00"), EndTime: ptr("17:00:00")}
+
+	tests := []struct {
+		name  string
+		rule  models.PricingRule
+		slot  pricingSlot
+		match bool
+	}{
+		{name: "overnight before midnight", rule: overnight, slot: testSlot("23:30:00"), match: true},
+		{name: "overnight after midnight", rule: overnight, slot: testSlot("01:15:00"), match: true},
+		{name: "overnight start boundary", rule: overnight, slot: testSlot("22:00:00"), match: true},
+		{name: "overnight end boundary", rule: overnight, slot: testSlot("02:00:00"), match: true},
+		{name: "overnight outside the range", rule: overnight, slot: testSlot("13:00:00"), match: false},
+		{name: "daytime end boundary", rule: daytime, slot: testSlot("17:00:00"), match: true},
+		{name: "daytime outside the range", rule: daytime, slot: testSlot("19:30:00"), match: false},
+		{name: "weekend on saturday", rule: models.PricingRule{Days: []int{6, 7}}, slot: testSlot("19:30:00"), match: true},
+		{name: "weekday on saturday", rule: models.PricingRule{Days: []int{1, 2, 3, 4, 5}}, slot: testSlot("19:30:00"), match: false},
+		{name: "other cinema", rule: models.PricingRule{CinemaID: ptr(2)}, slot: testSlot("19:30:00"), match: false},
+		{name: "holiday only on a holiday", rule: models.PricingRule{Holiday: ptr(true)}, slot: testSlot("19:30:00"), match: true},
+		{name: "not on holidays on a holiday", rule: models.PricingRule{Holiday: ptr(false)}, slot: testSlot("19:30:00"), match: false},
+		{name: "date range end day", rule: models.PricingRule{StartDate: ptr("2026-10-01"), EndDate: ptr("2026-10-17")}, slot: testSlot("19:30:00"), match: true},
+		{name: "date range not started", rule: models.PricingRule{StartDate: ptr("2026-10-18")}, slot: testSlot("19:30:00"), match: false},
+	}
+
+	engine := &pricingEngine{holidays: map[string]string{"2026-10-17": "Libur Test"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := engine.mismatches(tt.rule, tt.slot)
+			if match := len(reasons) == 0; match != tt.match {
+				t.Errorf("mismatches() = %v, want match %v", reasons, tt.match)
+			}
+		})
+	}
+}
+
+func TestPricingEngineResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []models.PricingRule
+		slot      pricingSlot
+		wantPrice int
+		wantRule  int
+	}{
+		{
+			name:      "no rule matches",
+			rules:     []models.PricingRule{{ID: 1, Days: []int{1}, Price: ptr(40)}},
+			slot:      testSlot("19:30:00"),
+			wantPrice: 50,
+		},
+		{
+			name: "higher priority wins over a more specific rule",
+			rules: []models.PricingRule{
+				{ID: 1, CinemaID: ptr(1), Days: []int{6}, Price: ptr(60), Priority: 1},
+				{ID: 2, Price: ptr(70), Priority: 5},
+			},
+			slot:      testSlot("19:30:00"),
+			wantPrice: 70,
+			wantRule:  2,
+		},
+		{
+			name: "more specific rule wins a priority tie",
+			rules: []models.PricingRule{
+				{ID: 2, Days: []int{6, 7}, Modifier: 10},
+				{ID: 1, Days: []int{6, 7}, StartTime: ptr("22:00:00"), EndTime: ptr("02:00:00"), Modifier: 20},
+			},
+			slot:      testSlot("23:00:00"),
+			wantPrice: 70,
+			wantRule:  1,
+		},
+		{
+			name: "newer rule wins a priority and specificity tie",
+			rules: []models.PricingRule{
+				{ID: 3, Days: []int{6}, Modifier: 5},
+				{ID: 4, CinemaID: ptr(1), Modifier: 15},
+			},
+			slot:      testSlot("19:30:00"),
+			wantPrice: 65,
+			wantRule:  4,
+		},
+		{
+			name: "tie winner that does not match falls through",
+			rules: []models.PricingRule{
+				{ID: 3, Days: []int{6}, Modifier: 5},
+				{ID: 4, CinemaID: ptr(2), Modifier: 15},
+			},
+			slot:      testSlot("19:30:00"),
+			wantPrice: 55,
+			wantRule:  3,
+		},
+		{
+			name:      "modifier never goes below zero",
+			rules:     []models.PricingRule{{ID: 1, Modifier: -80}},
+			slot:      testSlot("19:30:00"),
+			wantPrice: 0,
+			wantRule:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortPricingRules(tt.rules)
+			engine := &pricingEngine{rules: tt.rules, holidays: map[string]string{}}
+
+			price, rule := engine.resolve(tt.slot)
+			ruleID := 0
+			if rule != nil {
+				ruleID = rule.ID
+			}
+			if price != tt.wantPrice || ruleID != tt.wantRule {
+				t.Errorf("resolve() = %d (rule %d), want %d (rule %d)", price, ruleID, tt.wantPrice, tt.wantRule)
+			}
+		})
+	}
+}
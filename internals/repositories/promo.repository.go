@@ -0,0 +1,348 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPromoNotFound       = errors.New("promo code not found")
+	ErrPromoExists         = errors.New("promo code already used")
+	ErrPromoTargetNotFound = errors.New("movie, cinema or payment method of the promo not found")
+)
+
+// Alasan promo code ditolak saat quote / order
+const (
+	PromoReasonNotFound      = "not_found"
+	PromoReasonInactive      = "inactive"
+	PromoReasonNotStarted    = "not_started"
+	PromoReasonExpired       = "expired"
+	PromoReasonMinSpend      = "min_spend"
+	PromoReasonMovie         = "movie"
+	PromoReasonCinema        = "cinema"
+	PromoReasonPaymentMethod = "payment_method"
+	PromoReasonUsageLimit    = "usage_limit"
+	PromoReasonUserLimit     = "user_limit"
+)
+
+var promoReasonMessages = map[string]string{
+	PromoReasonNotFound:      "promo code not found",
+	PromoReasonInactive:      "promo code is not active",
+	PromoReasonNotStarted:    "promo code is not valid yet",
+	PromoReasonExpired:       "promo code has expired",
+	PromoReasonMinSpend:      "order does not reach the minimum spend of the promo code",
+	PromoReasonMovie:         "promo code is not valid for this movie",
+	PromoReasonCinema:        "promo code is not valid for this cinema",
+	PromoReasonPaymentMethod: "promo code is not valid for this payment method",
+	PromoReasonUsageLimit:    "promo code has reached its usage limit",
+	PromoReasonUserLimit:     "you have used this promo code the maximum number of times",
+}
+
+// PromoError dikembalikan ketika promo code tidak bisa dipakai untuk order
+type PromoError struct {
+	Code     string
+	Reason   string
+	MinSpend *int
+}
+
+func (e *PromoError) Error() string {
+	return promoReasonMessages[e.Reason]
+}
+
+const promoCodeColumns = `
+	p.id, p.code, p.description, p.discount_type, p.discount_value, p.max_discount, p.min_spend,
+	p.start_at, p.end_at, p.usage_limit, p.per_user_limit, p.active,
+	COALESCE((SELECT ARRAY_AGG(pm.id_movie ORDER BY pm.id_movie) FROM promo_code_movie pm WHERE pm.id_promo = p.id), '{}'),
+	COALESCE((SELECT ARRAY_AGG(pc.id_cinema ORDER BY pc.id_cinema) FROM promo_code_cinema pc WHERE pc.id_promo = p.id), '{}'),
+	COALESCE((SELECT ARRAY_AGG(pp.id_payment_method ORDER BY pp.id_payment_method) FROM promo_code_payment_method pp WHERE pp.id_promo = p.id), '{}'),
+	(SELECT COUNT(*) FROM promo_redemption r WHERE r.id_promo = p.id AND r.released_at IS NULL)
+`
+
+type PromoRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPromoRepo(db *pgxpool.Pool) *PromoRepo {
+	return &PromoRepo{DB: db}
+}
+
+func (r *PromoRepo) GetAll(ctx context.Context) ([]models.PromoCode, error) {
+	rows, err := r.DB.Query(ctx, `SELECT `+promoCodeColumns+` FROM promo_code p WHERE p.delete_at IS NULL ORDER BY p.id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.PromoCode])
+}
+
+func getPromoCode(ctx context.Context, db querier, id int) (*models.PromoCode, error) {
+	rows, err := db.Query(ctx, `SELECT `+promoCodeColumns+` FROM promo_code p WHERE p.id = $1 AND p.delete_at IS NULL`, id)
+	if err != nil {
+		return nil, err
+	}
+	promo, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByPos[models.PromoCode])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPromoNotFound
+		}
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (r *PromoRepo) GetByID(ctx context.Context, id int) (*models.PromoCode, error) {
+	return getPromoCode(ctx, r.DB, id)
+}
+
+// setPromoTargets mengganti pembatasan movie, cinema dan payment method promo
+func setPromoTargets(ctx context.Context, tx pgx.Tx, promoID int, req models.PromoCodeRequest) error {
+	targets := []struct {
+		table  string
+		column string
+		ids    []int
+	}{
+		{"promo_code_movie", "id_movie", req.MovieIDs},
+		{"promo_code_cinema", "id_cinema", req.CinemaIDs},
+		{"promo_code_payment_method", "id_payment_method", req.PaymentMethodIDs},
+	}
+
+	for _, t := range targets {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id_promo = $1`, t.table), promoID); err != nil {
+			return err
+		}
+		if len(t.ids) == 0 {
+			continue
+		}
+		query := fmt.Sprintf(`INSERT INTO %s (id_promo, %s) SELECT $1, UNNEST($2::int[])`, t.table, t.column)
+		if _, err := tx.Exec(ctx, query, promoID, t.ids); err != nil {
+			if isForeignKeyViolation(err) {
+				return ErrPromoTargetNotFound
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PromoRepo) Create(ctx context.Context, req models.PromoCodeRequest) (*models.PromoCode, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO promo_code (code, description, discount_type, discount_value, max_discount, min_spend, start_at, end_at, usage_limit, per_user_limit, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11, TRUE))
+		RETURNING id;
+	`
+
+	var id int
+	err = tx.QueryRow(ctx, query,
+		strings.ToUpper(req.Code), req.Description, req.DiscountType, req.DiscountValue, req.MaxDiscount, req.MinSpend,
+		req.StartAt, req.EndAt, req.UsageLimit, req.PerUserLimit, req.Active,
+	).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrPromoExists
+		}
+		return nil, err
+	}
+
+	if err := setPromoTargets(ctx, tx, id, req); err != nil {
+		return nil, err
+	}
+
+	promo, err := getPromoCode(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+// Update mengganti seluruh isi promo. Order yang sudah memakai promo tetap memakai diskon saat dibuat.
+func (r *PromoRepo) Update(ctx context.Context, id int, req models.PromoCodeRequest) (*models.PromoCode, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE promo_code
+		SET code = $1, description = $2, discount_type = $3, discount_value = $4, max_discount = $5, min_spend = $6,
+			start_at = $7, end_at = $8, usage_limit = $9, per_user_limit = $10, active = COALESCE($11, active), update_at = NOW()
+		WHERE id = $12 AND delete_at IS NULL;
+	`
+
+	tag, err := tx.Exec(ctx, query,
+		strings.ToUpper(req.Code), req.Description, req.DiscountType, req.DiscountValue, req.MaxDiscount, req.MinSpend,
+		req.StartAt, req.EndAt, req.UsageLimit, req.PerUserLimit, req.Active, id,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrPromoExists
+		}
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrPromoNotFound
+	}
+
+	if err := setPromoTargets(ctx, tx, id, req); err != nil {
+		return nil, err
+	}
+
+	promo, err := getPromoCode(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+// Delete soft delete supaya riwayat redemption tetap utuh
+func (r *PromoRepo) Delete(ctx context.Context, id int) error {
+	tag, err := r.DB.Exec(ctx, `UPDATE promo_code SET delete_at = NOW() WHERE id = $1 AND delete_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPromoNotFound
+	}
+	return nil
+}
+
+// promoCheck data order yang dicocokkan dengan syarat promo.
+// PaymentMethodID 0 melewati pembatasan payment method. Batas per user dihitung dari UserID,
+// untuk checkout tamu (UserID 0) dihitung dari Email order tamu, dan dilewati bila keduanya kosong (quote tanpa login).
+type promoCheck struct {
+	Code            string
+	ScheduleID      int
+	PaymentMethodID int
+	UserID          int
+	Email           string
+}
+
+// applyPromo memvalidasi promo code lalu mengisi diskon quote, mengembalikan id promo.
+// Di dalam transaction order baris promo dikunci (lock = true) sampai redemption tersimpan,
+// sehingga order yang balapan untuk promo yang sama dihitung bergantian dan kuota tidak bisa terlewati.
+func applyPromo(ctx context.Context, db querier, check promoCheck, quote *models.OrderQuote, lock bool) (int, error) {
+	code := strings.ToUpper(strings.TrimSpace(check.Code))
+
+	query := `SELECT id FROM promo_code WHERE code = $1 AND delete_at IS NULL`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+	var promoID int
+	if err := db.QueryRow(ctx, query, code).Scan(&promoID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, &PromoError{Code: code, Reason: PromoReasonNotFound}
+		}
+		return 0, err
+	}
+
+	// Hitungan redemption dibaca setelah baris promo terkunci supaya melihat order lain yang sudah commit
+	query = `
+		SELECT
+			p.description, p.discount_type, p.discount_value, p.max_discount, p.min_spend,
+			p.usage_limit, p.per_user_limit, p.active,
+			LOCALTIMESTAMP < p.start_at, LOCALTIMESTAMP > p.end_at,
+			NOT EXISTS (SELECT 1 FROM promo_code_movie pm WHERE pm.id_promo = p.id)
+				OR EXISTS (SELECT 1 FROM promo_code_movie pm WHERE pm.id_promo = p.id AND pm.id_movie = s.id_movie),
+			NOT EXISTS (SELECT 1 FROM promo_code_cinema pc WHERE pc.id_promo = p.id)
+				OR EXISTS (SELECT 1 FROM promo_code_cinema pc WHERE pc.id_promo = p.id AND pc.id_cinema = s.id_cinema),
+			$3::int = 0
+				OR NOT EXISTS (SELECT 1 FROM promo_code_payment_method pp WHERE pp.id_promo = p.id)
+				OR EXISTS (SELECT 1 FROM promo_code_payment_method pp WHERE pp.id_promo = p.id AND pp.id_payment_method = $3),
+			(SELECT COUNT(*) FROM promo_redemption r WHERE r.id_promo = p.id AND r.released_at IS NULL),
+			(SELECT COUNT(*) FROM promo_redemption r JOIN orders o ON o.id = r.id_order
+				WHERE r.id_promo = p.id AND r.released_at IS NULL
+					AND (r.id_user = $4 OR ($4::int = 0 AND r.id_user IS NULL AND LOWER(o.email) = LOWER($5))))
+		FROM promo_code p
+		JOIN schedule s ON s.id = $2
+		WHERE p.id = $1;
+	`
+
+	var (
+		promo                        models.PromoCode
+		notStarted, expired          bool
+		movieOK, cinemaOK, paymentOK bool
+		used, usedByUser             int
+	)
+	err := db.QueryRow(ctx, query, promoID, check.ScheduleID, check.PaymentMethodID, check.UserID, check.Email).Scan(
+		&promo.Description, &promo.DiscountType, &promo.DiscountValue, &promo.MaxDiscount, &promo.MinSpend,
+		&promo.UsageLimit, &promo.PerUserLimit, &promo.Active,
+		&notStarted, &expired, &movieOK, &cinemaOK, &paymentOK, &used, &usedByUser,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrScheduleNotFound
+		}
+		return 0, err
+	}
+
+	reject := func(reason string) (int, error) {
+		return 0, &PromoError{Code: code, Reason: reason}
+	}
+	switch {
+	case !promo.Active:
+		return reject(PromoReasonInactive)
+	case notStarted:
+		return reject(PromoReasonNotStarted)
+	case expired:
+		return reject(PromoReasonExpired)
+	case !movieOK:
+		return reject(PromoReasonMovie)
+	case !cinemaOK:
+		return reject(PromoReasonCinema)
+	case !paymentOK:
+		return reject(PromoReasonPaymentMethod)
+	case quote.Subtotal < promo.MinSpend:
+		return 0, &PromoError{Code: code, Reason: PromoReasonMinSpend, MinSpend: &promo.MinSpend}
+	case promo.UsageLimit != nil && used >= *promo.UsageLimit:
+		return reject(PromoReasonUsageLimit)
+	case (check.UserID != 0 || check.Email != "") && promo.PerUserLimit != nil && usedByUser >= *promo.PerUserLimit:
+		return reject(PromoReasonUserLimit)
+	}
+
+	discount := promo.DiscountValue
+	if promo.DiscountType == models.PromoDiscountPercent {
+		discount = quote.Subtotal * promo.DiscountValue / 100
+		if promo.MaxDiscount != nil {
+			discount = min(discount, *promo.MaxDiscount)
+		}
+	}
+	discount = min(discount, quote.Subtotal)
+
+	quote.Promo = &models.OrderPromo{Code: code, Description: promo.Description, Discount: discount}
+	quote.Discount += discount
+	quote.Total = quote.Subtotal - quote.Discount
+	return promoID, nil
+}
+
+// recordPromoRedemption mencatat pemakaian promo di transaction yang sama dengan order
+func recordPromoRedemption(ctx context.Context, tx pgx.Tx, promoID, orderID, userID, discount int) error {
+	query := `INSERT INTO promo_redemption (id_promo, id_order, id_user, discount) VALUES ($1, $2, NULLIF($3, 0), $4)`
+	_, err := tx.Exec(ctx, query, promoID, orderID, userID, discount)
+	return err
+}
+
+// releasePromoRedemptions mengembalikan kuota promo dari order yang expired / dibatalkan
+func releasePromoRedemptions(ctx context.Context, db querier, orderIDs []int) error {
+	query := `UPDATE promo_redemption SET released_at = NOW() WHERE id_order = ANY($1::int[]) AND released_at IS NULL`
+	_, err := db.Exec(ctx, query, orderIDs)
+	return err
+}
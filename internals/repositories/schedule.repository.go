@@ -17,6 +17,7 @@ func NewScheduleRepo(db *pgxpool.Pool) *ScheduleRepo {
 }
 
 // Schedule fetch schedules with optional filters: date, location, showTime
+// Harga yang ditampilkan adalah harga dasar dari pricing rule, sama dengan yang dipakai saat checkout
 func (r *ScheduleRepo) Schedule(ctx context.Context, movieID int, date, location, showTime *string) ([]models.Schedule, error) {
 	query := `
 		SELECT 
@@ -26,7 +27,9 @@ func (r *ScheduleRepo) Schedule(ctx context.Context, movieID int, date, location
 			c.logo AS cinema_img,
 			c.price,
 			l.name AS location,
-			t.time AS show_time
+			t.time AS show_time,
+			s.id_cinema,
+			t.time::text
 		FROM schedule s
 		JOIN cinema c   ON s.id_cinema = c.id
 		JOIN location l ON s.id_location = l.id
@@ -57,6 +60,12 @@ func (r *ScheduleRepo) Schedule(ctx context.Context, movieID int, date, location
 
 	query += " ORDER BY s.date, t.time;"
 
+	// rule dimuat sebelum query schedule supaya tidak butuh koneksi kedua selama rows masih terbuka
+	engine, err := loadPricingEngine(ctx, r.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pricing rules: %w", err)
+	}
+
 	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load schedule: %w", err)
@@ -65,7 +74,10 @@ func (r *ScheduleRepo) Schedule(ctx context.Context, movieID int, date, location
 
 	var schedules []models.Schedule
 	for rows.Next() {
-		var s models.Schedule
+		var (
+			s    models.Schedule
+			slot pricingSlot
+		)
 		if err := rows.Scan(
 			&s.ID,
 			&s.Date,
@@ -74,9 +86,18 @@ func (r *ScheduleRepo) Schedule(ctx context.Context, movieID int, date, location
 			&s.Price,
 			&s.Location,
 			&s.ShowTime,
+			&slot.CinemaID,
+			&slot.Time,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan schedule row: %w", err)
 		}
+
+		slot.Date, slot.CinemaPrice = s.Date, s.Price
+		price, rule := engine.resolve(slot)
+		s.Price = price
+		if rule != nil {
+			s.PricingRule = &rule.Name
+		}
 		schedules = append(schedules, s)
 	}
 
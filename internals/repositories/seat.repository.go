@@ -2,25 +2,222 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 )
 
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrSeatNotFound     = errors.New("seat not found")
+	ErrHoldNotFound     = errors.New("seat hold not found or expired")
+	ErrHoldLimitReached = errors.New("seat hold cannot be extended any further")
+	ErrSeatDisabled     = errors.New("seat is not available for sale")
+	ErrSeatPairRequired = errors.New("couple seats must be booked in pairs")
+	ErrWheelchairSeat   = errors.New("wheelchair spaces can only be booked with the accessibility flag")
+	ErrCompanionSeat    = errors.New("companion seats can only be booked together with the wheelchair space next to them")
+)
+
+// Hold kursi disimpan dalam satu hash per schedule: field = id kursi, value = "<owner>|<expired unix ms>|<mulai hold unix ms>".
+// Semua perubahan lewat lua script supaya cek + tulis untuk banyak kursi terjadi secara atomic.
+// Kursi yang masih ditahan owner yang sama tidak ditulis ulang, perpanjangan hanya lewat extendSeatsScript yang dibatasi.
+var holdSeatsScript = redis.NewScript(`
+local now = tonumber(ARGV[2])
+local expiry = tonumber(ARGV[3])
+local conflicts = {}
+local fresh = {}
+for i = 4, #ARGV do
+	local v = redis.call('HGET', KEYS[1], ARGV[i])
+	local owner, exp = nil, 0
+	if v then
+		local sep = string.find(v, '|', 1, true)
+		owner = string.sub(v, 1, sep - 1)
+		exp = tonumber(string.match(v, '^[^|]*|(%d+)')) or 0
+	end
+	if exp > now and owner ~= ARGV[1] then
+		table.insert(conflicts, ARGV[i])
+	elseif exp <= now then
+		table.insert(fresh, ARGV[i])
+	end
+end
+if #conflicts > 0 then
+	return conflicts
+end
+for _, seat in ipairs(fresh) do
+	redis.call('HSET', KEYS[1], seat, ARGV[1] .. '|' .. ARGV[3] .. '|' .. ARGV[2])
+end
+if #fresh > 0 and redis.call('PTTL', KEYS[1]) < expiry - now then
+	redis.call('PEXPIREAT', KEYS[1], expiry)
+end
+return conflicts
+`)
+
+var releaseSeatsScript = redis.NewScript(`
+local prefix = ARGV[1] .. '|'
+local seats = {}
+if #ARGV > 1 then
+	for i = 2, #ARGV do
+		table.insert(seats, ARGV[i])
+	end
+else
+	seats = redis.call('HKEYS', KEYS[1])
+end
+local released = {}
+for _, seat in ipairs(seats) do
+	local v = redis.call('HGET', KEYS[1], seat)
+	if v and string.sub(v, 1, #prefix) == prefix then
+		redis.call('HDEL', KEYS[1], seat)
+		table.insert(released, seat)
+	end
+end
+return released
+`)
+
+// extendSeatsScript mengembalikan {kursi yang diperpanjang, kursi yang melewati batas}. Jika ada kursi yang expired barunya
+// melewati mulai hold + ARGV[4] tidak ada kursi yang diperpanjang. Hold lama tanpa waktu mulai dihitung mulai sekarang.
+var extendSeatsScript = redis.NewScript(`
+local prefix = ARGV[1] .. '|'
+local now = tonumber(ARGV[2])
+local expiry = tonumber(ARGV[3])
+local limit = tonumber(ARGV[4])
+local seats = {}
+if #ARGV > 4 then
+	for i = 5, #ARGV do
+		table.insert(seats, ARGV[i])
+	end
+else
+	seats = redis.call('HKEYS', KEYS[1])
+end
+local extended, capped, started = {}, {}, {}
+for _, seat in ipairs(seats) do
+	local v = redis.call('HGET', KEYS[1], seat)
+	if v and string.sub(v, 1, #prefix) == prefix then
+		local exp, start = string.match(string.sub(v, #prefix + 1), '^(%d+)|?(%d*)$')
+		if exp and tonumber(exp) > now then
+			start = tonumber(start) or now
+			if expiry > start + limit then
+				table.insert(capped, seat)
+			else
+				table.insert(extended, seat)
+				table.insert(started, start)
+			end
+		end
+	end
+end
+if #capped > 0 then
+	return {{}, capped}
+end
+for i, seat in ipairs(extended) do
+	redis.call('HSET', KEYS[1], seat, prefix .. ARGV[3] .. '|' .. started[i])
+end
+if #extended > 0 and redis.call('PTTL', KEYS[1]) < expiry - now then
+	redis.call('PEXPIREAT', KEYS[1], expiry)
+end
+return {extended, capped}
+`)
+
+// SeatLimitError dikembalikan ketika jumlah kursi melewati batas per order atau per akun untuk satu schedule
+type SeatLimitError struct {
+	Scope      string
+	ScheduleID int
+	Limit      int
+	Used       int
+	Requested  int
+}
+
+func (e *SeatLimitError) Error() string {
+	if e.Scope == models.SeatLimitScopeOrder {
+		return fmt.Sprintf("an order can contain at most %d seats", e.Limit)
+	}
+	return fmt.Sprintf("at most %d seats per account for schedule %d, %d already held or booked", e.Limit, e.ScheduleID, e.Used)
+}
+
+// SeatLimits batas kursi anti calo, nilainya dari konfigurasi
+type SeatLimits struct {
+	PerOrder        int
+	PerUserSchedule int
+}
+
+// ownedSeats jumlah kursi aktif milik pemesan di satu schedule. userID 0 berarti tamu, dihitung dari email order tamu.
+func ownedSeats(ctx context.Context, db querier, scheduleID, userID int, email string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM orderdetails d
+		JOIN orders o ON o.id = d.id_order
+		WHERE d.id_schedule = $1
+			AND d.released_at IS NULL
+			AND (o.id_user = $2 OR ($2::int = 0 AND o.id_user IS NULL AND LOWER(o.email) = LOWER($3)))
+	`
+	var owned int
+	err := db.QueryRow(ctx, query, scheduleID, userID, email).Scan(&owned)
+	return owned, err
+}
+
+// seatLimitLockKey key advisory lock batas kursi per schedule dan pemesan. Checkout dan hold memakai key yang sama supaya
+// request paralel dari pemesan yang sama dihitung bergantian, guest (email atau owner hold) dipakai ketika userID 0.
+func seatLimitLockKey(scheduleID, userID int, guest string) string {
+	if userID != 0 {
+		return fmt.Sprintf("seat-limit-%d-%d", scheduleID, userID)
+	}
+	return fmt.Sprintf("seat-limit-%d-%d-%s", scheduleID, userID, guest)
+}
+
 type SeatRepository struct {
-	DB *pgxpool.Pool
+	DB  *pgxpool.Pool
+	RDB *redis.Client
+}
+
+func NewSeatRepository(db *pgxpool.Pool, rdb *redis.Client) *SeatRepository {
+	return &SeatRepository{DB: db, RDB: rdb}
+}
+
+// HoldOwnerUser identitas pemilik hold untuk user yang login
+func HoldOwnerUser(userID int) string {
+	return fmt.Sprintf("user-%d", userID)
+}
+
+// HoldOwnerGuest identitas pemilik hold untuk sesi checkout tamu (header X-Guest-Token)
+func HoldOwnerGuest(token string) string {
+	return fmt.Sprintf("guest-%s", token)
+}
+
+// HoldOwnerSeatChange identitas pemilik hold kursi baru dari tukar kursi yang menunggu pembayaran selisih.
+// User tidak bisa melepas atau memperpanjang hold ini karena owner-nya bukan HoldOwnerUser.
+func HoldOwnerSeatChange(changeID int) string {
+	return fmt.Sprintf("seatchange-%d", changeID)
 }
 
-func NewSeatRepository(db *pgxpool.Pool) *SeatRepository {
-	return &SeatRepository{DB: db}
+func seatHoldKey(scheduleID int) string {
+	return fmt.Sprintf("Ntisrangga142-SeatHold-%d", scheduleID)
+}
+
+// parseSeatHold memecah value hash menjadi owner dan waktu expired
+func parseSeatHold(val string) (string, time.Time, bool) {
+	owner, rest, found := strings.Cut(val, "|")
+	if !found {
+		return "", time.Time{}, false
+	}
+	expiry, _, _ := strings.Cut(rest, "|")
+	ms, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return owner, time.UnixMilli(ms), true
 }
 
 func (r *SeatRepository) GetSoldSeats(ctx context.Context, scheduleID int) (models.Seat, error) {
 	query := `
-		SELECT od.id_seat
-		FROM orders o
-		JOIN orderdetails od ON o.id = od.id_order
-		WHERE o.id_schedule = $1;
+		SELECT id_seat
+		FROM orderdetails
+		WHERE id_schedule = $1 AND released_at IS NULL;
 	`
 
 	rows, err := r.DB.Query(ctx, query, scheduleID)
@@ -29,7 +226,7 @@ func (r *SeatRepository) GetSoldSeats(ctx context.Context, scheduleID int) (mode
 	}
 	defer rows.Close()
 
-	seats := models.Seat{ID: []string{}}
+	seats := models.Seat{ID: []string{}, Held: []string{}}
 	for rows.Next() {
 		var s string
 		if err := rows.Scan(&s); err != nil {
@@ -38,5 +235,486 @@ func (r *SeatRepository) GetSoldSeats(ctx context.Context, scheduleID int) (mode
 		seats.ID = append(seats.ID, s)
 	}
 
+	holds, err := r.activeHolds(ctx, scheduleID)
+	if err != nil {
+		return models.Seat{}, err
+	}
+	for seat := range holds {
+		if !slices.Contains(seats.ID, seat) {
+			seats.Held = append(seats.Held, seat)
+		}
+	}
+	slices.Sort(seats.Held)
+
 	return seats, nil
 }
+
+// GetSeatMap mengembalikan denah studio schedule beserta status setiap kursi.
+// accessibleRelease jarak sebelum jam tayang ketika ruang kursi roda dan kursi pendamping dibuka untuk umum.
+func (r *SeatRepository) GetSeatMap(ctx context.Context, scheduleID int, accessibleRelease time.Duration) (*models.SeatResponse, error) {
+	query := `
+		SELECT a.id, a.name, a.layout, LOCALTIMESTAMP + make_interval(secs => $2) >= s.date + t.time
+		FROM schedule s
+		JOIN auditorium a ON a.id = s.id_auditorium
+		JOIN time t ON t.id = s.id_time
+		WHERE s.id = $1 AND s.delete_at IS NULL;
+	`
+
+	res := models.SeatResponse{ScheduleID: scheduleID}
+	err := r.DB.QueryRow(ctx, query, scheduleID, accessibleRelease.Seconds()).Scan(&res.AuditoriumID, &res.Auditorium, &res.Layout, &res.AccessibleReleased)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+
+	sold, err := r.GetSoldSeats(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	res.Seat = sold.ID
+	res.Held = sold.Held
+
+	prices, err := seatPrices(ctx, r.DB, scheduleID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Seats = make([]models.SeatMapSeat, 0, len(prices))
+	for _, seat := range prices {
+		status := models.SeatStatusAvailable
+		switch {
+		case seat.Disabled:
+			status = models.SeatStatusDisabled
+		case slices.Contains(sold.ID, seat.ID):
+			status = models.SeatStatusSold
+		case slices.Contains(sold.Held, seat.ID):
+			status = models.SeatStatusHeld
+		}
+		res.Seats = append(res.Seats, models.SeatMapSeat{
+			ID:     seat.ID,
+			Row:    seat.Row,
+			Column: seat.Column,
+			Class:  seat.Class,
+			Price:  seat.Price,
+			Pair:   seat.Pair,
+			Access: seat.Access,
+			Status: status,
+		})
+	}
+
+	return &res, nil
+}
+
+// seatPrice kursi studio schedule beserta kelas dan harganya
+type seatPrice struct {
+	ID          string
+	Row         string
+	Column      int
+	Disabled    bool
+	Pair        *string
+	Access      *string
+	SeatClassID int
+	Class       string
+	ClassName   string
+	BasePrice   int
+	Price       int
+}
+
+// seatPrices mengambil harga kursi schedule, seats kosong berarti semua kursi di studio.
+// Harga kursi = seat_class.price jika diisi, atau harga dasar dari pricing rule + seat_class.modifier.
+func seatPrices(ctx context.Context, db querier, scheduleID int, seats []string) ([]seatPrice, error) {
+	basePrice, err := schedulePrice(ctx, db, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			a.id_seat, a.row_label, a.col_number, a.disabled, a.pair_seat, a.access,
+			sc.id, sc.code, sc.name, $3::int, COALESCE(sc.price, $3::int + sc.modifier)
+		FROM schedule s
+		JOIN auditorium_seat a ON a.id_auditorium = s.id_auditorium
+		JOIN seat_class sc ON sc.id = a.id_seat_class
+		WHERE s.id = $1 AND ($2::varchar[] IS NULL OR a.id_seat = ANY($2::varchar[]))
+		ORDER BY a.row_label, a.col_number;
+	`
+
+	rows, err := db.Query(ctx, query, scheduleID, seats, basePrice)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[seatPrice])
+}
+
+// activeHolds mengembalikan kursi yang masih ditahan (belum expired) beserta pemiliknya
+func (r *SeatRepository) activeHolds(ctx context.Context, scheduleID int) (map[string]string, error) {
+	values, err := r.RDB.HGetAll(ctx, seatHoldKey(scheduleID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	holds := make(map[string]string, len(values))
+	for seat, val := range values {
+		owner, expiry, ok := parseSeatHold(val)
+		if ok && expiry.After(now) {
+			holds[seat] = owner
+		}
+	}
+	return holds, nil
+}
+
+// validateSeats memastikan schedule masih aktif dan semua kursi ada di denah studio schedule tersebut
+func validateSeats(ctx context.Context, db querier, scheduleID int, seats []string) error {
+	var auditoriumID int
+	err := db.QueryRow(ctx, `SELECT id_auditorium FROM schedule WHERE id = $1 AND delete_at IS NULL`, scheduleID).Scan(&auditoriumID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScheduleNotFound
+		}
+		return err
+	}
+
+	query := `SELECT id_seat, disabled, pair_seat FROM auditorium_seat WHERE id_auditorium = $1 AND id_seat = ANY($2::varchar[])`
+	rows, err := db.Query(ctx, query, auditoriumID, seats)
+	if err != nil {
+		return err
+	}
+	type seatInfo struct {
+		ID       string
+		Disabled bool
+		Pair     *string
+	}
+	infos, err := pgx.CollectRows(rows, pgx.RowToStructByPos[seatInfo])
+	if err != nil {
+		return err
+	}
+	known := make(map[string]seatInfo, len(infos))
+	for _, info := range infos {
+		known[info.ID] = info
+	}
+
+	var unknown, unavailable, unpaired []string
+	for _, seat := range seats {
+		info, ok := known[seat]
+		switch {
+		case !ok:
+			unknown = append(unknown, seat)
+		case info.Disabled:
+			unavailable = append(unavailable, seat)
+		case info.Pair != nil && !slices.Contains(seats, *info.Pair):
+			unpaired = append(unpaired, fmt.Sprintf("%s needs %s", seat, *info.Pair))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrSeatNotFound, strings.Join(unknown, ", "))
+	}
+	if len(unavailable) > 0 {
+		return fmt.Errorf("%w: %s", ErrSeatDisabled, strings.Join(unavailable, ", "))
+	}
+	if len(unpaired) > 0 {
+		return fmt.Errorf("%w: %s", ErrSeatPairRequired, strings.Join(unpaired, ", "))
+	}
+	return nil
+}
+
+// checkAccessibleSeats ruang kursi roda hanya untuk pemesan dengan flag aksesibilitas, dan kursi pendamping hanya bersama
+// ruang kursi roda di sebelahnya dalam seleksi yang sama. Sejak accessibleRelease sebelum jam tayang keduanya dijual umum.
+// Jenis kursi dibaca dari auditorium_seat.access, denah hanya dipakai untuk posisi lorong.
+func checkAccessibleSeats(ctx context.Context, db querier, scheduleID int, seats []string, accessible bool, accessibleRelease time.Duration) error {
+	query := `
+		SELECT a.id, a.layout, LOCALTIMESTAMP + make_interval(secs => $2) >= s.date + t.time
+		FROM schedule s
+		JOIN auditorium a ON a.id = s.id_auditorium
+		JOIN time t ON t.id = s.id_time
+		WHERE s.id = $1 AND s.delete_at IS NULL
+	`
+	var auditoriumID int
+	var layout models.SeatLayout
+	var released bool
+	if err := db.QueryRow(ctx, query, scheduleID, accessibleRelease.Seconds()).Scan(&auditoriumID, &layout, &released); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScheduleNotFound
+		}
+		return err
+	}
+	if released {
+		return nil
+	}
+
+	query = `SELECT id_seat, access FROM auditorium_seat WHERE id_auditorium = $1 AND id_seat = ANY($2::varchar[]) AND access IS NOT NULL`
+	rows, err := db.Query(ctx, query, auditoriumID, seats)
+	if err != nil {
+		return err
+	}
+	type seatAccess struct {
+		ID     string
+		Access string
+	}
+	accessSeats, err := pgx.CollectRows(rows, pgx.RowToStructByPos[seatAccess])
+	if err != nil {
+		return err
+	}
+	access := make(map[string]string, len(accessSeats))
+	for _, seat := range accessSeats {
+		access[seat.ID] = seat.Access
+	}
+
+	var wheelchair, companion []string
+	for _, seat := range seats {
+		switch access[seat] {
+		case models.SeatAccessWheelchair:
+			wheelchair = append(wheelchair, seat)
+		case models.SeatAccessCompanion:
+			companion = append(companion, seat)
+		}
+	}
+	if len(wheelchair) > 0 && !accessible {
+		return fmt.Errorf("%w: %s", ErrWheelchairSeat, strings.Join(wheelchair, ", "))
+	}
+
+	var unpaired []string
+	for _, seat := range companion {
+		if !slices.ContainsFunc(wheelchair, func(w string) bool { return adjacentSeats(layout, seat, w) }) {
+			unpaired = append(unpaired, seat)
+		}
+	}
+	if len(unpaired) > 0 {
+		return fmt.Errorf("%w: %s", ErrCompanionSeat, strings.Join(unpaired, ", "))
+	}
+	return nil
+}
+
+// HoldSeats menahan kursi untuk owner selama ttl, kursi yang sudah ditahan owner tetap memakai expired lamanya. Kursi yang sudah terjual atau ditahan orang lain
+// menggagalkan seluruh request dengan SeatConflictError, seleksi yang melanggar aturan bioskop dengan OrphanSeatError,
+// dan hold yang melewati batas kursi dengan SeatLimitError. Batas kursi dicek dan hold dipasang di bawah advisory lock
+// yang sama dengan CreateOrder, sehingga hold paralel dari pemesan yang sama tidak bisa bersama-sama lolos cek batas.
+func (r *SeatRepository) HoldSeats(ctx context.Context, scheduleID int, owner string, userID int, seats []string, accessible bool, limits SeatLimits, ttl, accessibleRelease time.Duration) (time.Time, error) {
+	if err := validateSeats(ctx, r.DB, scheduleID, seats); err != nil {
+		return time.Time{}, err
+	}
+
+	// tx hanya dipakai untuk memegang advisory lock sampai hold terpasang
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, seatLimitLockKey(scheduleID, userID, owner)); err != nil {
+		return time.Time{}, err
+	}
+	if err := r.checkHoldLimit(ctx, scheduleID, owner, userID, seats, limits); err != nil {
+		return time.Time{}, err
+	}
+	if err := checkAccessibleSeats(ctx, r.DB, scheduleID, seats, accessible, accessibleRelease); err != nil {
+		return time.Time{}, err
+	}
+
+	query := `SELECT id_seat FROM orderdetails WHERE id_schedule = $1 AND id_seat = ANY($2::varchar[]) AND released_at IS NULL`
+	rows, err := r.DB.Query(ctx, query, scheduleID, seats)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sold, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(sold) > 0 {
+		return time.Time{}, &SeatConflictError{ScheduleID: scheduleID, Seats: sold}
+	}
+	if err := r.CheckSeatRules(ctx, scheduleID, owner, seats, nil); err != nil {
+		return time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	args := []any{owner, now.UnixMilli(), expiresAt.UnixMilli()}
+	for _, seat := range seats {
+		args = append(args, seat)
+	}
+
+	conflicts, err := holdSeatsScript.Run(ctx, r.RDB, []string{seatHoldKey(scheduleID)}, args...).StringSlice()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(conflicts) > 0 {
+		return time.Time{}, &SeatConflictError{ScheduleID: scheduleID, Seats: conflicts}
+	}
+
+	// kursi yang sudah ditahan owner tidak ikut diperpanjang, expired seleksi mengikuti kursi yang paling cepat habis
+	values, err := r.RDB.HMGet(ctx, seatHoldKey(scheduleID), seats...).Result()
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, v := range values {
+		val, _ := v.(string)
+		if _, expiry, ok := parseSeatHold(val); ok && expiry.Before(expiresAt) {
+			expiresAt = expiry
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, nil
+}
+
+// HoldSeatChange memindahkan hold kursi baru tukar kursi dari owner ke HoldOwnerSeatChange(changeID) sampai expiresAt.
+// Kursi yang ditahan orang lain atau terjual sebelum hold terpasang menggagalkan hold dengan SeatConflictError.
+func (r *SeatRepository) HoldSeatChange(ctx context.Context, scheduleID int, owner string, changeID int, seats []string, expiresAt time.Time) error {
+	if _, err := r.ReleaseSeats(ctx, scheduleID, owner, seats); err != nil {
+		return err
+	}
+
+	changeOwner := HoldOwnerSeatChange(changeID)
+	args := []any{changeOwner, time.Now().UnixMilli(), expiresAt.UnixMilli()}
+	for _, seat := range seats {
+		args = append(args, seat)
+	}
+	conflicts, err := holdSeatsScript.Run(ctx, r.RDB, []string{seatHoldKey(scheduleID)}, args...).StringSlice()
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &SeatConflictError{ScheduleID: scheduleID, Seats: conflicts}
+	}
+
+	// checkout yang selesai sebelum hold terpasang sudah tercatat di orderdetails
+	query := `SELECT id_seat FROM orderdetails WHERE id_schedule = $1 AND id_seat = ANY($2::varchar[]) AND released_at IS NULL`
+	rows, err := r.DB.Query(ctx, query, scheduleID, seats)
+	if err != nil {
+		return err
+	}
+	sold, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	if len(sold) > 0 {
+		if _, err := r.ReleaseSeats(ctx, scheduleID, changeOwner, seats); err != nil {
+			return err
+		}
+		return &SeatConflictError{ScheduleID: scheduleID, Seats: sold}
+	}
+	return nil
+}
+
+// checkHoldLimit memastikan hold baru tidak membuat owner melewati batas kursi.
+// Kursi yang sudah di-hold owner tidak dihitung dua kali, dan untuk user yang login kursi yang sudah dibeli ikut dihitung.
+func (r *SeatRepository) checkHoldLimit(ctx context.Context, scheduleID int, owner string, userID int, seats []string, limits SeatLimits) error {
+	holds, err := r.activeHolds(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	held, requested := 0, 0
+	for _, o := range holds {
+		if o == owner {
+			held++
+		}
+	}
+	for _, seat := range seats {
+		if holds[seat] != owner {
+			requested++
+		}
+	}
+
+	if held+requested > limits.PerOrder {
+		return &SeatLimitError{Scope: models.SeatLimitScopeOrder, ScheduleID: scheduleID, Limit: limits.PerOrder, Used: held, Requested: requested}
+	}
+
+	owned := 0
+	if userID != 0 {
+		if owned, err = ownedSeats(ctx, r.DB, scheduleID, userID, ""); err != nil {
+			return err
+		}
+	}
+	if owned+held+requested > limits.PerUserSchedule {
+		return &SeatLimitError{Scope: models.SeatLimitScopeSchedule, ScheduleID: scheduleID, Limit: limits.PerUserSchedule, Used: owned + held, Requested: requested}
+	}
+	return nil
+}
+
+// ReleaseSeats melepas hold milik owner. Jika seats kosong semua hold owner di schedule tersebut dilepas.
+func (r *SeatRepository) ReleaseSeats(ctx context.Context, scheduleID int, owner string, seats []string) ([]string, error) {
+	args := []any{owner}
+	for _, seat := range seats {
+		args = append(args, seat)
+	}
+
+	released, err := releaseSeatsScript.Run(ctx, r.RDB, []string{seatHoldKey(scheduleID)}, args...).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// ExtendHold memperpanjang hold milik owner yang masih aktif. Jika seats kosong semua hold owner diperpanjang.
+// Satu hold tidak bisa bertahan lebih dari ttl + maxExtension sejak pertama kali ditahan, melewatinya ditolak dengan ErrHoldLimitReached.
+func (r *SeatRepository) ExtendHold(ctx context.Context, scheduleID int, owner string, seats []string, ttl, maxExtension time.Duration) ([]string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	args := []any{owner, now.UnixMilli(), expiresAt.UnixMilli(), (ttl + maxExtension).Milliseconds()}
+	for _, seat := range seats {
+		args = append(args, seat)
+	}
+
+	res, err := extendSeatsScript.Run(ctx, r.RDB, []string{seatHoldKey(scheduleID)}, args...).Slice()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	extended, capped := holdSeatList(res[0]), holdSeatList(res[1])
+	if len(capped) > 0 {
+		return nil, time.Time{}, fmt.Errorf("%w: %s", ErrHoldLimitReached, strings.Join(capped, ", "))
+	}
+	if len(extended) == 0 {
+		return nil, time.Time{}, ErrHoldNotFound
+	}
+	return extended, expiresAt, nil
+}
+
+// holdSeatList mengubah array kursi hasil lua script menjadi []string
+func holdSeatList(v any) []string {
+	items, _ := v.([]any)
+	seats := make([]string, 0, len(items))
+	for _, item := range items {
+		if seat, ok := item.(string); ok {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// MissingHolds mengembalikan kursi yang tidak sedang ditahan oleh owner
+func (r *SeatRepository) MissingHolds(ctx context.Context, scheduleID int, owner string, seats []string) ([]string, error) {
+	holds, err := r.activeHolds(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, seat := range seats {
+		if holds[seat] != owner {
+			missing = append(missing, seat)
+		}
+	}
+	return missing, nil
+}
+
+// ForeignHolds mengembalikan kursi yang sedang ditahan owner lain
+func (r *SeatRepository) ForeignHolds(ctx context.Context, scheduleID int, owner string, seats []string) ([]string, error) {
+	holds, err := r.activeHolds(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var held []string
+	for _, seat := range seats {
+		if o, ok := holds[seat]; ok && o != owner {
+			held = append(held, seat)
+		}
+	}
+	return held, nil
+}
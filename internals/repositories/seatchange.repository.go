@@ -0,0 +1,526 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrSeatChangeStatus       = errors.New("seats can only be changed on pending, awaiting payment or paid orders")
+	ErrSeatChangeCutoffPassed = errors.New("seats can no longer be changed this close to the showtime")
+	ErrSeatChangeCount        = errors.New("the new seats must match the number of booked seats")
+	ErrSeatChangeUnchanged    = errors.New("the new seats are the same as the booked seats")
+	ErrSeatChangePending      = errors.New("order already has a seat change waiting for payment")
+)
+
+// SeatChangeSettlement tukar kursi pending yang selesai karena charge selisihnya lunas, gagal atau expired.
+// Hold kursi baru milik HoldOwnerSeatChange(ChangeID) harus dilepas, Refund berisi pengembalian charge
+// yang terlanjur dibayar padahal penukarannya tidak bisa diterapkan.
+type SeatChangeSettlement struct {
+	ChangeID   int
+	OrderID    int
+	ScheduleID int
+	Status     string
+	ToSeats    []string
+	Refund     *models.Refund
+}
+
+// pendingSeatChange data tukar kursi yang dibutuhkan untuk menerapkan penukaran saat charge-nya lunas
+type pendingSeatChange struct {
+	ID              int
+	OrderID         int
+	ScheduleID      int
+	OrderStatus     string
+	Status          string
+	FromSeats       []string
+	ToSeats         []string
+	SeatClassIDs    []int
+	Prices          []int
+	PriceDifference int
+	ChangedBy       int
+	PaymentID       int
+	Amount          int
+}
+
+// lockSeatChangePayment mengunci tukar kursi dan order milik charge reference, nil jika charge bukan tagihan tukar kursi
+func lockSeatChangePayment(ctx context.Context, tx pgx.Tx, reference string) (*pendingSeatChange, error) {
+	query := `
+		SELECT
+			sc.id, sc.id_order, o.id_schedule, o.status, sc.status, sc.from_seats, sc.to_seats,
+			COALESCE(sc.to_seat_classes, '{}'), COALESCE(sc.to_prices, '{}'), sc.price_difference, sc.changed_by, p.id, p.amount
+		FROM seat_change sc
+		JOIN payments p ON p.id = sc.id_payment
+		JOIN orders o ON o.id = sc.id_order
+		WHERE p.reference = $1
+		FOR UPDATE OF sc, o
+	`
+	rows, err := tx.Query(ctx, query, reference)
+	if err != nil {
+		return nil, err
+	}
+	change, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByPos[pendingSeatChange])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return change, nil
+}
+
+// ChangeSeats menukar kursi order dengan kursi baru di schedule yang sama dalam satu transaction. Baris orderdetails
+// kursi lama diubah langsung ke kursi baru sehingga unique index (id_schedule, id_seat) menggagalkan seluruh penukaran
+// jika ada kursi tujuan yang sudah terjual. Kursi baru membawa harga bayar kursi lama ditambah selisih harga kelasnya saat ini.
+// Charge yang masih pending pada order yang belum lunas dibatalkan supaya pembayaran berikutnya memakai total baru,
+// order yang sudah lunas mendapat refund pending untuk selisih negatif. Selisih positif pada order lunas hanya dicatat
+// sebagai tukar kursi pending sampai expiresAt, penukarannya diterapkan SettlePayment saat charge dari handler dibayar.
+// Poin order lunas disesuaikan dengan earnRate setiap kali totalnya berubah.
+func (r *OrderRepo) ChangeSeats(ctx context.Context, orderID, changedBy int, seats []string, expiresAt time.Time, cutoff, accessibleRelease time.Duration, earnRate int) (*models.SeatChange, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT o.status, o.checkin_at IS NOT NULL, o.id_schedule, o.accessibility, o.total_price, LOCALTIMESTAMP + make_interval(secs => $2) > s.date + t.time
+		FROM orders o
+		JOIN schedule s ON s.id = o.id_schedule
+		JOIN time t ON t.id = s.id_time
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`
+
+	var status string
+	var scheduleID, totalPrice int
+	var checkedIn, accessible, passed bool
+	if err := tx.QueryRow(ctx, query, orderID, cutoff.Seconds()).Scan(&status, &checkedIn, &scheduleID, &accessible, &totalPrice, &passed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if !slices.Contains([]string{models.OrderStatusPending, models.OrderStatusAwaitingPayment, models.OrderStatusPaid}, status) {
+		return nil, ErrSeatChangeStatus
+	}
+	if checkedIn {
+		return nil, ErrOrderCheckedIn
+	}
+	if passed {
+		return nil, ErrSeatChangeCutoffPassed
+	}
+	transferred, err := isTransferred(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if transferred {
+		return nil, ErrOrderTransferred
+	}
+
+	var pending bool
+	query = `SELECT EXISTS (SELECT 1 FROM seat_change WHERE id_order = $1 AND status = $2)`
+	if err := tx.QueryRow(ctx, query, orderID, models.SeatChangeStatusPending).Scan(&pending); err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrSeatChangePending
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id_seat, price FROM orderdetails WHERE id_order = $1 AND released_at IS NULL`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	type bookedSeat struct {
+		ID    string
+		Price int
+	}
+	booked, err := pgx.CollectRows(rows, pgx.RowToStructByPos[bookedSeat])
+	if err != nil {
+		return nil, err
+	}
+	if len(booked) != len(seats) {
+		return nil, fmt.Errorf("%w: %d booked, %d requested", ErrSeatChangeCount, len(booked), len(seats))
+	}
+
+	paid := make(map[string]int, len(booked))
+	var from, to []string
+	for _, seat := range booked {
+		paid[seat.ID] = seat.Price
+		if !slices.Contains(seats, seat.ID) {
+			from = append(from, seat.ID)
+		}
+	}
+	for _, seat := range seats {
+		if _, ok := paid[seat]; !ok {
+			to = append(to, seat)
+		}
+	}
+	if len(to) == 0 {
+		return nil, ErrSeatChangeUnchanged
+	}
+	slices.Sort(from)
+	slices.Sort(to)
+
+	if err := validateSeats(ctx, tx, scheduleID, seats); err != nil {
+		return nil, err
+	}
+	if err := checkAccessibleSeats(ctx, tx, scheduleID, seats, accessible, accessibleRelease); err != nil {
+		return nil, err
+	}
+
+	query = `SELECT id_seat FROM orderdetails WHERE id_schedule = $1 AND id_seat = ANY($2::varchar[]) AND released_at IS NULL ORDER BY id_seat`
+	rows, err = tx.Query(ctx, query, scheduleID, to)
+	if err != nil {
+		return nil, err
+	}
+	taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	if len(taken) > 0 {
+		return nil, &SeatConflictError{ScheduleID: scheduleID, Seats: taken}
+	}
+
+	prices, err := seatPrices(ctx, tx, scheduleID, slices.Concat(from, to))
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]seatPrice, len(prices))
+	for _, p := range prices {
+		current[p.ID] = p
+	}
+
+	// pasangan kursi lama dan baru tidak memengaruhi total selisih, diurutkan supaya hasilnya stabil
+	classIDs := make([]int, 0, len(to))
+	newPrices := make([]int, 0, len(to))
+	difference := 0
+	for i := range to {
+		price := max(paid[from[i]]+current[to[i]].Price-current[from[i]].Price, 0)
+		classIDs = append(classIDs, current[to[i]].SeatClassID)
+		newPrices = append(newPrices, price)
+		difference += price - paid[from[i]]
+	}
+
+	change := models.SeatChange{OrderID: orderID, FromSeats: from, ToSeats: to, Status: status}
+	if status == models.OrderStatusPaid && difference > 0 {
+		// order lunas baru ditukar setelah charge selisihnya dibayar, sampai saat itu kursi baru ditahan oleh handler
+		change.ChangeStatus = models.SeatChangeStatusPending
+		change.PriceDifference = difference
+		change.TotalPrice = totalPrice + difference
+		change.ExpiresAt = &expiresAt
+
+		query = `
+			INSERT INTO seat_change (id_order, from_seats, to_seats, to_seat_classes, to_prices, price_difference, changed_by, status, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id, create_at
+		`
+		err := tx.QueryRow(ctx, query, orderID, from, to, classIDs, newPrices, difference, changedBy, change.ChangeStatus, expiresAt).Scan(&change.ID, &change.CreatedAt)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return nil, ErrSeatChangePending
+			}
+			return nil, err
+		}
+	} else {
+		change.ChangeStatus = models.SeatChangeStatusApplied
+
+		query = `
+			UPDATE orderdetails d
+			SET id_seat = v.to_seat, id_seat_class = v.id_seat_class, price = v.price
+			FROM UNNEST($2::varchar[], $3::varchar[], $4::int[], $5::int[]) AS v(from_seat, to_seat, id_seat_class, price)
+			WHERE d.id_order = $1 AND d.id_seat = v.from_seat AND d.released_at IS NULL
+		`
+		if _, err := tx.Exec(ctx, query, orderID, from, to, classIDs, newPrices); err != nil {
+			if isUniqueViolation(err) {
+				return nil, &SeatConflictError{ScheduleID: scheduleID, Seats: to}
+			}
+			return nil, err
+		}
+
+		query = `UPDATE orders SET total_price = GREATEST(total_price + $2, 0), update_at = NOW() WHERE id = $1 RETURNING total_price`
+		if err := tx.QueryRow(ctx, query, orderID, difference).Scan(&change.TotalPrice); err != nil {
+			return nil, err
+		}
+		change.PriceDifference = change.TotalPrice - totalPrice
+
+		if status != models.OrderStatusPaid && change.PriceDifference != 0 {
+			query = `UPDATE payments SET status = $1, update_at = NOW() WHERE id_order = $2 AND status = $3`
+			if _, err := tx.Exec(ctx, query, payments.ChargeStatusExpired, orderID, payments.ChargeStatusPending); err != nil {
+				return nil, err
+			}
+			query = `UPDATE virtual_account SET status = $1, update_at = NOW() WHERE id_order = $2 AND status = $3`
+			if _, err := tx.Exec(ctx, query, payments.ChargeStatusExpired, orderID, payments.ChargeStatusPending); err != nil {
+				return nil, err
+			}
+		}
+
+		query = `
+			INSERT INTO seat_change (id_order, from_seats, to_seats, price_difference, changed_by)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, create_at
+		`
+		if err := tx.QueryRow(ctx, query, orderID, from, to, change.PriceDifference, changedBy).Scan(&change.ID, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		if status == models.OrderStatusPaid && change.PriceDifference < 0 {
+			change.Refund, err = createRefund(ctx, tx, orderID, changedBy, -change.PriceDifference, "seat change")
+			if err != nil {
+				return nil, err
+			}
+			if _, err := tx.Exec(ctx, `UPDATE seat_change SET id_refund = $1 WHERE id = $2`, change.Refund.ID, change.ID); err != nil {
+				return nil, err
+			}
+			if err := adjustOrderPoints(ctx, tx, orderID, change.PriceDifference, earnRate); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	change.Seat = slices.Clone(seats)
+	slices.Sort(change.Seat)
+	return &change, nil
+}
+
+// RecordSeatChangePayment menyimpan charge selisih tukar kursi. Order tetap paid, charge ini dilunasi lewat webhook biasa
+// dan SettlePayment yang menerapkan penukarannya.
+func (r *OrderRepo) RecordSeatChangePayment(ctx context.Context, changeID int, payment models.Payment) (*models.Payment, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO payments (id_order, provider, reference, amount, status, payment_url, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, create_at
+	`
+	err = tx.QueryRow(ctx, query,
+		payment.OrderID,
+		payment.Provider,
+		payment.Reference,
+		payment.Amount,
+		payment.Status,
+		payment.PaymentURL,
+		payment.ExpiresAt,
+	).Scan(&payment.ID, &payment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE seat_change SET id_payment = $1 WHERE id = $2`, payment.ID, changeID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// settleSeatChange menerapkan atau membatalkan tukar kursi pending sesuai status charge selisihnya.
+// Charge yang lunas tetapi penukarannya tidak bisa diterapkan (kursi sudah terjual, order batal, atau tukar kursi
+// sudah expired) mendapat refund pending sebesar charge tersebut.
+func settleSeatChange(ctx context.Context, tx pgx.Tx, change *pendingSeatChange, status string, earnRate int) (*SeatChangeSettlement, error) {
+	settlement := &SeatChangeSettlement{
+		ChangeID:   change.ID,
+		OrderID:    change.OrderID,
+		ScheduleID: change.ScheduleID,
+		Status:     change.Status,
+		ToSeats:    change.ToSeats,
+	}
+
+	switch {
+	case status == payments.ChargeStatusPaid:
+		if change.Status == models.SeatChangeStatusPending {
+			applied, err := applySeatChange(ctx, tx, change, earnRate)
+			if err != nil {
+				return nil, err
+			}
+			if applied {
+				settlement.Status = models.SeatChangeStatusApplied
+				return settlement, nil
+			}
+			if err := closeSeatChange(ctx, tx, change.ID, models.SeatChangeStatusFailed); err != nil {
+				return nil, err
+			}
+			settlement.Status = models.SeatChangeStatusFailed
+		}
+
+		refund, err := refundSeatChangePayment(ctx, tx, change)
+		if err != nil {
+			return nil, err
+		}
+		settlement.Refund = refund
+	case change.Status == models.SeatChangeStatusPending && (status == payments.ChargeStatusExpired || status == payments.ChargeStatusFailed):
+		closed := models.SeatChangeStatusExpired
+		if status == payments.ChargeStatusFailed {
+			closed = models.SeatChangeStatusFailed
+		}
+		if err := closeSeatChange(ctx, tx, change.ID, closed); err != nil {
+			return nil, err
+		}
+		settlement.Status = closed
+	}
+
+	return settlement, nil
+}
+
+// applySeatChange memindahkan baris orderdetails ke kursi baru dan menambah total order. Savepoint dipakai supaya
+// unique violation karena kursi baru sudah terjual hanya membatalkan penukaran, bukan pencatatan pembayarannya.
+// Selisih yang dibayar ikut dikreditkan sebagai poin dengan earnRate.
+func applySeatChange(ctx context.Context, tx pgx.Tx, change *pendingSeatChange, earnRate int) (bool, error) {
+	if change.OrderStatus != models.OrderStatusPaid {
+		return false, nil
+	}
+
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer sp.Rollback(ctx)
+
+	query := `
+		UPDATE orderdetails d
+		SET id_seat = v.to_seat, id_seat_class = v.id_seat_class, price = v.price
+		FROM UNNEST($2::varchar[], $3::varchar[], $4::int[], $5::int[]) AS v(from_seat, to_seat, id_seat_class, price)
+		WHERE d.id_order = $1 AND d.id_seat = v.from_seat AND d.released_at IS NULL
+	`
+	tag, err := sp.Exec(ctx, query, change.OrderID, change.FromSeats, change.ToSeats, change.SeatClassIDs, change.Prices)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if int(tag.RowsAffected()) != len(change.FromSeats) {
+		return false, nil
+	}
+
+	query = `UPDATE orders SET total_price = total_price + $2, update_at = NOW() WHERE id = $1`
+	if _, err := sp.Exec(ctx, query, change.OrderID, change.PriceDifference); err != nil {
+		return false, err
+	}
+	query = `UPDATE seat_change SET status = $1, update_at = NOW() WHERE id = $2`
+	if _, err := sp.Exec(ctx, query, models.SeatChangeStatusApplied, change.ID); err != nil {
+		return false, err
+	}
+	if err := adjustOrderPoints(ctx, sp, change.OrderID, change.PriceDifference, earnRate); err != nil {
+		return false, err
+	}
+
+	if err := sp.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// refundSeatChangePayment membuat refund pending untuk seluruh charge selisih tukar kursi
+func refundSeatChangePayment(ctx context.Context, tx pgx.Tx, change *pendingSeatChange) (*models.Refund, error) {
+	query := `
+		INSERT INTO refunds (id_order, id_payment, amount, status, reason, cancelled_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, id_order, id_payment, amount, status, reason, create_at
+	`
+	var refund models.Refund
+	err := tx.QueryRow(ctx, query, change.OrderID, change.PaymentID, change.Amount, payments.ChargeStatusPending, "seat change not applied", change.ChangedBy).Scan(
+		&refund.ID, &refund.OrderID, &refund.PaymentID, &refund.Amount, &refund.Status, &refund.Reason, &refund.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(ctx, `SELECT provider, reference FROM payments WHERE id = $1`, change.PaymentID).Scan(&refund.Provider, &refund.PaymentReference); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE seat_change SET id_refund = $1 WHERE id = $2`, refund.ID, change.ID); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// closeSeatChange menutup tukar kursi pending beserta charge selisihnya yang belum dibayar
+func closeSeatChange(ctx context.Context, db querier, changeID int, status string) error {
+	query := `UPDATE seat_change SET status = $1, update_at = NOW() WHERE id = $2 AND status = $3`
+	if _, err := db.Exec(ctx, query, status, changeID, models.SeatChangeStatusPending); err != nil {
+		return err
+	}
+	query = `UPDATE payments SET status = $1, update_at = NOW() WHERE id = (SELECT id_payment FROM seat_change WHERE id = $2) AND status = $3`
+	_, err := db.Exec(ctx, query, payments.ChargeStatusExpired, changeID, payments.ChargeStatusPending)
+	return err
+}
+
+// CloseSeatChange menandai tukar kursi pending gagal, dipakai handler ketika kursi baru tidak bisa ditahan atau charge gagal dibuat
+func (r *OrderRepo) CloseSeatChange(ctx context.Context, changeID int) error {
+	return closeSeatChange(ctx, r.DB, changeID, models.SeatChangeStatusFailed)
+}
+
+// ExpireSeatChanges meng-expire tukar kursi pending yang charge selisihnya tidak dibayar sampai expires_at.
+// SKIP LOCKED membuat beberapa instance bisa berjalan bersamaan tanpa memproses tukar kursi yang sama.
+func (r *OrderRepo) ExpireSeatChanges(ctx context.Context, limit int) ([]SeatChangeSettlement, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE seat_change sc
+		SET status = $1, update_at = NOW()
+		FROM orders o
+		WHERE o.id = sc.id_order AND sc.id IN (
+			SELECT id
+			FROM seat_change
+			WHERE status = $2 AND expires_at < NOW()
+			ORDER BY id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING sc.id, sc.id_order, o.id_schedule, sc.status, sc.to_seats
+	`
+	rows, err := tx.Query(ctx, query, models.SeatChangeStatusExpired, models.SeatChangeStatusPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	expired, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (SeatChangeSettlement, error) {
+		var s SeatChangeSettlement
+		err := row.Scan(&s.ChangeID, &s.OrderID, &s.ScheduleID, &s.Status, &s.ToSeats)
+		return s, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	changeIDs := make([]int, 0, len(expired))
+	for _, s := range expired {
+		changeIDs = append(changeIDs, s.ChangeID)
+	}
+	query = `
+		UPDATE payments SET status = $1, update_at = NOW()
+		WHERE id IN (SELECT id_payment FROM seat_change WHERE id = ANY($2::int[])) AND status = $3
+	`
+	if _, err := tx.Exec(ctx, query, payments.ChargeStatusExpired, changeIDs, payments.ChargeStatusPending); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
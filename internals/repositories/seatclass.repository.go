@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrSeatClassNotFound = errors.New("seat class not found")
+	ErrSeatClassExists   = errors.New("seat class code already used")
+)
+
+type SeatClassRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewSeatClassRepo(db *pgxpool.Pool) *SeatClassRepo {
+	return &SeatClassRepo{DB: db}
+}
+
+func collectSeatClasses(rows pgx.Rows) ([]models.SeatClass, error) {
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.SeatClass])
+}
+
+func (r *SeatClassRepo) GetAll(ctx context.Context) ([]models.SeatClass, error) {
+	rows, err := r.DB.Query(ctx, `SELECT id, code, name, price, modifier, pairs_only FROM seat_class ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return collectSeatClasses(rows)
+}
+
+// seatClassesByCode daftar kelas kursi yang dipakai saat membangun denah
+func seatClassesByCode(ctx context.Context, db querier) (map[string]models.SeatClass, error) {
+	rows, err := db.Query(ctx, `SELECT id, code, name, price, modifier, pairs_only FROM seat_class`)
+	if err != nil {
+		return nil, err
+	}
+	classes, err := collectSeatClasses(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]models.SeatClass, len(classes))
+	for _, c := range classes {
+		res[c.Code] = c
+	}
+	return res, nil
+}
+
+func (r *SeatClassRepo) Create(ctx context.Context, req models.SeatClassRequest) (*models.SeatClass, error) {
+	query := `
+		INSERT INTO seat_class (code, name, price, modifier, pairs_only)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, code, name, price, modifier, pairs_only;
+	`
+
+	var c models.SeatClass
+	err := r.DB.QueryRow(ctx, query, req.Code, req.Name, req.Price, req.Modifier, req.PairsOnly).Scan(
+		&c.ID, &c.Code, &c.Name, &c.Price, &c.Modifier, &c.PairsOnly,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrSeatClassExists
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Update mengubah harga kelas kursi. Order yang sudah dibuat tetap memakai harga saat dibeli (orderdetails.price).
+// code dan pairs_only tidak bisa diubah karena dipakai oleh denah studio yang sudah ada.
+func (r *SeatClassRepo) Update(ctx context.Context, id int, req models.SeatClassUpdateRequest) (*models.SeatClass, error) {
+	query := `
+		UPDATE seat_class
+		SET name = $1, price = $2, modifier = $3, update_at = NOW()
+		WHERE id = $4
+		RETURNING id, code, name, price, modifier, pairs_only;
+	`
+
+	var c models.SeatClass
+	err := r.DB.QueryRow(ctx, query, req.Name, req.Price, req.Modifier, id).Scan(
+		&c.ID, &c.Code, &c.Name, &c.Price, &c.Modifier, &c.PairsOnly,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSeatClassNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxSeatSuggestions jumlah alternatif kursi yang dikembalikan saat seleksi ditolak
+const maxSeatSuggestions = 3
+
+// OrphanSeatError dikembalikan ketika seleksi kursi meninggalkan kursi kosong tunggal di bioskop yang mengaktifkan aturan no orphan seat
+type OrphanSeatError struct {
+	ScheduleID  int
+	Seats       []string
+	Suggestions [][]string
+}
+
+func (e *OrphanSeatError) Error() string {
+	return fmt.Sprintf("seat selection leaves a single empty seat for schedule %d: %s", e.ScheduleID, strings.Join(e.Seats, ", "))
+}
+
+// ruleSeat kursi studio yang dipakai untuk mengecek aturan pemilihan kursi
+type ruleSeat struct {
+	ID       string
+	Row      string
+	Column   int
+	Disabled bool
+	Pair     *string
+	Access   *string
+}
+
+// seatBlocks mengelompokkan kursi per baris menjadi blok kursi yang bersebelahan.
+// Lorong, gap dan kursi disabled memutus blok karena tidak bisa diduduki.
+func seatBlocks(seats []ruleSeat, aisles []int) [][]ruleSeat {
+	var blocks [][]ruleSeat
+	var block []ruleSeat
+	for _, seat := range seats {
+		if len(block) > 0 {
+			last := block[len(block)-1]
+			if seat.Disabled || last.Row != seat.Row || last.Column+1 != seat.Column || slices.Contains(aisles, last.Column) {
+				blocks = append(blocks, block)
+				block = nil
+			}
+		}
+		if !seat.Disabled {
+			block = append(block, seat)
+		}
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// blockOrphans kursi kosong tunggal dalam satu blok: kedua sisinya terisi atau ujung blok, dan minimal satu sisi terisi.
+// Blok berisi satu kursi yang kedua sisinya lorong tidak dihitung.
+func blockOrphans(block []ruleSeat, occupied map[string]bool) []string {
+	var orphans []string
+	for i, seat := range block {
+		if occupied[seat.ID] {
+			continue
+		}
+		leftTaken := i > 0 && occupied[block[i-1].ID]
+		rightTaken := i < len(block)-1 && occupied[block[i+1].ID]
+		leftClosed := i == 0 || leftTaken
+		rightClosed := i == len(block)-1 || rightTaken
+		if leftClosed && rightClosed && (leftTaken || rightTaken) {
+			orphans = append(orphans, seat.ID)
+		}
+	}
+	return orphans
+}
+
+// newOrphans kursi kosong tunggal yang muncul setelah selection diduduki dan belum ada sebelumnya
+func newOrphans(block []ruleSeat, before map[string]bool, selection []string) []string {
+	after := maps.Clone(before)
+	for _, seat := range selection {
+		after[seat] = true
+	}
+
+	existing := blockOrphans(block, before)
+	var orphans []string
+	for _, seat := range blockOrphans(block, after) {
+		if !slices.Contains(existing, seat) {
+			orphans = append(orphans, seat)
+		}
+	}
+	return orphans
+}
+
+// suggestSeats mencari deretan kursi kosong sebanyak selection dalam satu blok yang tidak meninggalkan kursi kosong tunggal
+// dan tidak memisahkan kursi couple. Ruang kursi roda dan kursi pendamping tidak pernah disarankan. Hasil diurutkan dari yang paling dekat dengan selection, baris lebih diutamakan dari kolom.
+func suggestSeats(blocks [][]ruleSeat, before map[string]bool, selection []string) [][]string {
+	rowIdx, selectionCols := -1, 0
+	for _, block := range blocks {
+		for _, seat := range block {
+			if !slices.Contains(selection, seat.ID) {
+				continue
+			}
+			if row := int(seat.Row[0] - 'A'); rowIdx == -1 || row < rowIdx {
+				rowIdx = row
+			}
+			selectionCols += seat.Column
+		}
+	}
+
+	type candidate struct {
+		seats   []string
+		rowDist int
+		colDist int
+	}
+	var candidates []candidate
+	size := len(selection)
+	for _, block := range blocks {
+		for start := 0; start+size <= len(block); start++ {
+			window := block[start : start+size]
+			ids := make([]string, 0, size)
+			usable := true
+			windowCols := 0
+			for _, seat := range window {
+				if before[seat.ID] || seat.Access != nil || (seat.Pair != nil && !slices.ContainsFunc(window, func(s ruleSeat) bool { return s.ID == *seat.Pair })) {
+					usable = false
+					break
+				}
+				ids = append(ids, seat.ID)
+				windowCols += seat.Column
+			}
+			if !usable || len(newOrphans(block, before, ids)) > 0 {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				seats:   ids,
+				rowDist: abs(int(window[0].Row[0]-'A') - rowIdx),
+				colDist: abs(windowCols - selectionCols),
+			})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.rowDist != b.rowDist {
+			return a.rowDist - b.rowDist
+		}
+		return a.colDist - b.colDist
+	})
+
+	suggestions := [][]string{}
+	for _, c := range candidates[:min(len(candidates), maxSeatSuggestions)] {
+		suggestions = append(suggestions, c.seats)
+	}
+	return suggestions
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// CheckSeatRules menjalankan aturan pemilihan kursi bioskop pada seats milik owner. Kursi yang terjual dan hold aktif
+// dianggap terisi, kecuali kursi freed yang akan dilepas oleh tukar kursi. Jika seleksi meninggalkan kursi kosong tunggal baru
+// dan masih ada alternatif yang tidak meninggalkannya, seleksi ditolak dengan OrphanSeatError. Tanpa alternatif seleksi
+// tetap diterima supaya kursi sisa masih bisa dijual.
+func (r *SeatRepository) CheckSeatRules(ctx context.Context, scheduleID int, owner string, seats, freed []string) error {
+	query := `
+		SELECT a.id, a.layout, c.no_orphan_seat
+		FROM schedule s
+		JOIN auditorium a ON a.id = s.id_auditorium
+		JOIN cinema c ON c.id = a.id_cinema
+		WHERE s.id = $1 AND s.delete_at IS NULL
+	`
+
+	var auditoriumID int
+	var layout models.SeatLayout
+	var noOrphanSeat bool
+	if err := r.DB.QueryRow(ctx, query, scheduleID).Scan(&auditoriumID, &layout, &noOrphanSeat); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScheduleNotFound
+		}
+		return err
+	}
+	if !noOrphanSeat {
+		return nil
+	}
+
+	holds, err := r.activeHolds(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+	before := map[string]bool{}
+	for seat, o := range holds {
+		if !slices.Contains(seats, seat) {
+			before[seat] = true
+		} else if o != owner {
+			// kursi yang ditahan orang lain dilaporkan sebagai konflik oleh pengecekan hold
+			return nil
+		}
+	}
+
+	rows, err := r.DB.Query(ctx, `SELECT id_seat FROM orderdetails WHERE id_schedule = $1 AND released_at IS NULL`, scheduleID)
+	if err != nil {
+		return err
+	}
+	sold, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	for _, seat := range sold {
+		if slices.Contains(seats, seat) {
+			return nil
+		}
+		if !slices.Contains(freed, seat) {
+			before[seat] = true
+		}
+	}
+
+	rows, err = r.DB.Query(ctx, `
+		SELECT id_seat, row_label, col_number, disabled, pair_seat, access
+		FROM auditorium_seat
+		WHERE id_auditorium = $1
+		ORDER BY row_label, col_number
+	`, auditoriumID)
+	if err != nil {
+		return err
+	}
+	studioSeats, err := pgx.CollectRows(rows, pgx.RowToStructByPos[ruleSeat])
+	if err != nil {
+		return err
+	}
+
+	blocks := seatBlocks(studioSeats, layout.Aisles)
+	var orphans []string
+	for _, block := range blocks {
+		orphans = append(orphans, newOrphans(block, before, seats)...)
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	suggestions := suggestSeats(blocks, before, seats)
+	if len(suggestions) == 0 {
+		return nil
+	}
+	return &OrphanSeatError{ScheduleID: scheduleID, Seats: orphans, Suggestions: suggestions}
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// testBlock blok kursi bersebelahan di satu baris, kolom dimulai dari 1
+func testBlock(row string, n int) []ruleSeat {
+	block := make([]ruleSeat, 0, n)
+	for col := 1; col <= n; col++ {
+		block = append(block, ruleSeat{ID: row + string(rune('0'+col)), Row: row, Column: col})
+	}
+	return block
+}
+
+func testOccupied(seats ...string) map[string]bool {
+	occupied := map[string]bool{}
+	for _, seat := range seats {
+		occupied[seat] = true
+	}
+	return occupied
+}
+
+func TestBlockOrphans(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    []ruleSeat
+		occupied map[string]bool
+		want     []string
+	}{
+		{name: "empty block", block: testBlock("A", 5), occupied: testOccupied(), want: nil},
+		{name: "left edge of row", block: testBlock("A", 5), occupied: testOccupied("A2"), want: []string{"A1"}},
+		{name: "right edge of row", block: testBlock("A", 5), occupied: testOccupied("A4"), want: []string{"A5"}},
+		{name: "between two taken seats", block: testBlock("A", 5), occupied: testOccupied("A1", "A3"), want: []string{"A2"}},
+		{name: "two free seats at the edge", block: testBlock("A", 5), occupied: testOccupied("A3"), want: nil},
+		{name: "single seat block between aisles", block: testBlock("A", 1), occupied: testOccupied(), want: nil},
+		{name: "full block", block: testBlock("A", 3), occupied: testOccupied("A1", "A2", "A3"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockOrphans(tt.block, tt.occupied); !slices.Equal(got, tt.want) {
+				t.Errorf("blockOrphans() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOrphans(t *testing.T) {
+	tests := []struct {
+		name      string
+		before    map[string]bool
+		selection []string
+		want      []string
+	}{
+		{name: "selection from the edge", before: testOccupied(), selection: []string{"A1", "A2"}, want: nil},
+		{name: "selection leaves the edge seat", before: testOccupied(), selection: []string{"A2", "A3"}, want: []string{"A1"}},
+		{name: "existing orphan is not reported again", before: testOccupied("A2"), selection: []string{"A4"}, want: []string{"A3", "A5"}},
+		{name: "selection fills an existing orphan", before: testOccupied("A2"), selection: []string{"A1"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newOrphans(testBlock("A", 5), tt.before, tt.selection); !slices.Equal(got, tt.want) {
+				t.Errorf("newOrphans() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestSeats(t *testing.T) {
+	wheelchair := "wheelchair"
+	accessible := testBlock("A", 3)
+	accessible[0].Access = &wheelchair
+
+	couple := testBlock("A", 4)
+	couple[0].Pair, couple[1].Pair = &couple[1].ID, &couple[0].ID
+	couple[2].Pair, couple[3].Pair = &couple[3].ID, &couple[2].ID
+
+	tests := []struct {
+		name      string
+		blocks    [][]ruleSeat
+		before    map[string]bool
+		selection []string
+		want      [][]string
+	}{
+		{
+			name:      "same row first, then nearest row",
+			blocks:    [][]ruleSeat{testBlock("A", 4), testBlock("B", 4)},
+			before:    testOccupied(),
+			selection: []string{"A2", "A3"},
+			want:      [][]string{{"A1", "A2"}, {"A3", "A4"}, {"B1", "B2"}},
+		},
+		{
+			name:      "windows leaving an edge orphan are skipped",
+			blocks:    [][]ruleSeat{testBlock("A", 5)},
+			before:    testOccupied("A1"),
+			selection: []string{"A3", "A4"},
+			want:      [][]string{{"A2", "A3"}, {"A4", "A5"}},
+		},
+		{
+			name:      "wheelchair space is never suggested",
+			blocks:    [][]ruleSeat{accessible},
+			before:    testOccupied(),
+			selection: []string{"A2"},
+			want:      [][]string{{"A3"}},
+		},
+		{
+			name:      "couple seats are not split",
+			blocks:    [][]ruleSeat{couple},
+			before:    testOccupied(),
+			selection: []string{"A2", "A3"},
+			want:      [][]string{{"A1", "A2"}, {"A3", "A4"}},
+		},
+		{
+			name:      "no alternative",
+			blocks:    [][]ruleSeat{testBlock("A", 3)},
+			before:    testOccupied("A1"),
+			selection: []string{"A2"},
+			want:      [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestSeats(tt.blocks, tt.before, tt.selection); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suggestSeats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -28,7 +30,6 @@ func (r *UserRepository) GetProfileByID(ctx context.Context, id int) (*models.Us
 		u.lastname,
 		u.phone,
 		u.profileimg,
-		u.virtual_account,
 		u.point,
 		a.email,
 		a.role
@@ -45,7 +46,6 @@ func (r *UserRepository) GetProfileByID(ctx context.Context, id int) (*models.Us
 		&user.LastName,
 		&user.Phone,
 		&user.ProfileImg,
-		&user.VirtualAccount,
 		&user.Point,
 		&user.Email,
 		&user.Role,
@@ -59,12 +59,17 @@ func (r *UserRepository) GetProfileByID(ctx context.Context, id int) (*models.Us
 	return &user, nil
 }
 
-func (r *UserRepository) GetHistoryByUserID(ctx context.Context, userID int) (models.OrderHistoryResponse, error) {
-	query := `
+// orderHistoryQuery query detail order untuk riwayat dan pencarian tiket,
+// %s pertama diisi kondisi WHERE dan %s kedua diisi ORDER BY (beserta LIMIT bila perlu)
+const orderHistoryQuery = `
 	SELECT 
 		o.id AS order_id,
-		o.ispaid,
+		o.status = 'paid' AS ispaid,
+		o.status,
+		o.expires_at,
+		o.paid_at,
 		o.total_price,
+		o.booking_code,
 		o.qrcode,
 		o.name AS order_name,
 		o.email AS order_email,
@@ -80,7 +85,23 @@ func (r *UserRepository) GetHistoryByUserID(ctx context.Context, userID int) (mo
 		m.backdrop AS movie_backdrop,
 		m.duration,
 		m.rating,
-		ARRAY_AGG(od.id_seat) AS seats
+		ARRAY_AGG(od.id_seat) AS seats,
+		COALESCE((
+			SELECT JSON_AGG(items ORDER BY items.class, items.unit_price)
+			FROM (
+				SELECT
+					sc.code AS class,
+					sc.name AS class_name,
+					ARRAY_AGG(d.id_seat ORDER BY d.id_seat) AS seats,
+					COUNT(*) AS quantity,
+					d.price AS unit_price,
+					SUM(d.price) AS subtotal
+				FROM orderdetails d
+				JOIN seat_class sc ON sc.id = d.id_seat_class
+				WHERE d.id_order = o.id
+				GROUP BY sc.code, sc.name, d.price
+			) items
+		), '[]') AS items
 	FROM orders o
 	JOIN payment_method pm ON o.id_payment_method = pm.id
 	JOIN schedule ns ON o.id_schedule = ns.id
@@ -89,17 +110,15 @@ func (r *UserRepository) GetHistoryByUserID(ctx context.Context, userID int) (mo
 	JOIN location l ON ns.id_location = l.id
 	JOIN time t ON ns.id_time = t.id
 	LEFT JOIN orderdetails od ON o.id = od.id_order
-	WHERE o.id_user = $1
+	WHERE %s
 	GROUP BY 
-		o.id, o.ispaid, o.total_price, o.qrcode, o.name, o.email, o.phone,
+		o.id, o.status, o.expires_at, o.paid_at, o.total_price, o.booking_code, o.qrcode, o.name, o.email, o.phone,
 		pm.name, ns.date, t.time, c.name, l.name, m.title, m.poster, m.backdrop, m.duration, m.rating, c.logo
-	ORDER BY o.id DESC;
-	`
+	ORDER BY %s;
+`
 
-	rows, err := r.db.Query(ctx, query, userID)
-	if err != nil {
-		return models.OrderHistoryResponse{}, err
-	}
+// collectOrderHistory membaca hasil orderHistoryQuery
+func collectOrderHistory(rows pgx.Rows) ([]models.OrderHistory, error) {
 	defer rows.Close()
 
 	var histories []models.OrderHistory
@@ -108,7 +127,11 @@ func (r *UserRepository) GetHistoryByUserID(ctx context.Context, userID int) (mo
 		err := rows.Scan(
 			&history.OrderID,
 			&history.IsPaid,
+			&history.Status,
+			&history.ExpiresAt,
+			&history.PaidAt,
 			&history.TotalPrice,
+			&history.BookingCode,
 			&history.QRCode,
 			&history.OrderName,
 			&history.OrderEmail,
@@ -125,16 +148,79 @@ func (r *UserRepository) GetHistoryByUserID(ctx context.Context, userID int) (mo
 			&history.Duration,
 			&history.Rating,
 			&history.Seats,
+			&history.Items,
 		)
 		if err != nil {
-			return models.OrderHistoryResponse{}, err
+			return nil, err
 		}
 		histories = append(histories, history)
 	}
+	return histories, rows.Err()
+}
+
+// historySorts urutan riwayat yang bisa dipilih, o.id menjadi pemutus supaya halaman stabil
+var historySorts = map[string]string{
+	models.HistorySortNewest:       "o.id DESC",
+	models.HistorySortOldest:       "o.id ASC",
+	models.HistorySortShowtimeAsc:  "ns.date + t.time ASC, o.id ASC",
+	models.HistorySortShowtimeDesc: "ns.date + t.time DESC, o.id DESC",
+}
+
+// GetHistoryByUserID mengambil satu halaman riwayat order user sesuai filter q, Page, Limit dan Sort wajib sudah terisi
+func (r *UserRepository) GetHistoryByUserID(ctx context.Context, userID int, q models.OrderHistoryQuery) (models.OrderHistoryResponse, error) {
+	conditions := []string{"o.id_user = $1"}
+	args := []any{userID}
+
+	switch q.When {
+	case models.HistoryUpcoming:
+		conditions = append(conditions, "ns.date + t.time >= LOCALTIMESTAMP")
+	case models.HistoryPast:
+		conditions = append(conditions, "ns.date + t.time < LOCALTIMESTAMP")
+	}
+	if q.Status != "" {
+		args = append(args, q.Status)
+		conditions = append(conditions, fmt.Sprintf("o.status = $%d", len(args)))
+	}
+	if q.MovieID != 0 {
+		args = append(args, q.MovieID)
+		conditions = append(conditions, fmt.Sprintf("ns.id_movie = $%d", len(args)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	res := models.OrderHistoryResponse{
+		UserID:      userID,
+		ListHistory: []models.OrderHistory{},
+		Pagination:  models.HistoryPagination{Page: q.Page, Limit: q.Limit},
+	}
 
-	var res models.OrderHistoryResponse
-	res.UserID = userID
-	res.ListHistory = histories
+	countQuery := `
+		SELECT COUNT(*)
+		FROM orders o
+		JOIN schedule ns ON o.id_schedule = ns.id
+		JOIN time t ON ns.id_time = t.id
+		WHERE ` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&res.Pagination.TotalItems); err != nil {
+		return res, err
+	}
+	res.Pagination.TotalPages = (res.Pagination.TotalItems + q.Limit - 1) / q.Limit
+	if res.Pagination.TotalItems == 0 {
+		return res, nil
+	}
+
+	args = append(args, q.Limit, (q.Page-1)*q.Limit)
+	order := fmt.Sprintf("%s LIMIT $%d OFFSET $%d", historySorts[q.Sort], len(args)-1, len(args))
+	rows, err := r.db.Query(ctx, fmt.Sprintf(orderHistoryQuery, where, order), args...)
+	if err != nil {
+		return res, err
+	}
+
+	histories, err := collectOrderHistory(rows)
+	if err != nil {
+		return res, err
+	}
+	if histories != nil {
+		res.ListHistory = histories
+	}
 
 	return res, nil
 }
@@ -164,11 +250,6 @@ func (r *UserRepository) UpdateUser(ctx context.Context, id int, data models.Upd
 		args = append(args, *data.Phone)
 		argID++
 	}
-	if data.VirtualAccount != nil && *data.VirtualAccount != "" {
-		setClauses = append(setClauses, fmt.Sprintf("virtual_account=$%d", argID))
-		args = append(args, *data.VirtualAccount)
-		argID++
-	}
 
 	if len(setClauses) == 0 {
 		return nil
@@ -183,18 +264,25 @@ func (r *UserRepository) UpdateUser(ctx context.Context, id int, data models.Upd
 	return err
 }
 
-func (r *UserRepository) GetVirtualAccountByID(ctx context.Context, userID int) (models.UserVA, error) {
-	var user models.UserVA
-
-	row := r.db.QueryRow(ctx, "SELECT virtual_account FROM users WHERE id=$1 LIMIT 1", userID)
-	if err := row.Scan(&user.VirtualAccount); err != nil {
-		if err == pgx.ErrNoRows {
-			return user, nil
-		}
-		return user, err
+// GetVirtualAccounts mengambil VA yang masih bisa ditransfer untuk order user yang menunggu pembayaran,
+// termasuk VA selisih tukar kursi pada order yang sudah lunas
+func (r *UserRepository) GetVirtualAccounts(ctx context.Context, userID int) ([]models.UserVA, error) {
+	query := `
+		SELECT va.number, pm.name, va.id_order, va.amount, va.expires_at, va.create_at
+		FROM virtual_account va
+		JOIN orders o ON o.id = va.id_order
+		JOIN payment_method pm ON pm.id = va.id_payment_method
+		WHERE o.id_user = $1
+			AND o.status = ANY($2::varchar[])
+			AND va.status = $3
+			AND (va.expires_at IS NULL OR va.expires_at > NOW())
+		ORDER BY va.expires_at ASC NULLS LAST, va.id DESC;
+	`
+	rows, err := r.db.Query(ctx, query, userID, []string{models.OrderStatusAwaitingPayment, models.OrderStatusPaid}, payments.ChargeStatusPending)
+	if err != nil {
+		return nil, err
 	}
-
-	return user, nil
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.UserVA])
 }
 
 func (r *UserRepository) GetPasswordByID(ctx context.Context, userID int) (string, error) {
@@ -216,3 +304,133 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, userID int, newPass
 	}
 	return nil
 }
+
+// GetPoints saldo poin user beserta ledger terbaru
+func (r *UserRepository) GetPoints(ctx context.Context, userID, limit int) (models.UserPoints, error) {
+	var res models.UserPoints
+	if err := r.db.QueryRow(ctx, `SELECT COALESCE(point, 0) FROM users WHERE id = $1`, userID).Scan(&res.Balance); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return res, err
+	}
+
+	query := `
+		SELECT id, id_order, points, reason, create_at
+		FROM point_ledger
+		WHERE id_user = $1
+		ORDER BY id DESC
+		LIMIT $2;
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return res, err
+	}
+	res.Ledger, err = pgx.CollectRows(rows, pgx.RowToStructByPos[models.PointLedgerEntry])
+	return res, err
+}
+
+// ClaimGuestOrders memindahkan order tamu ke akun user. Booking code menjadi bukti kepemilikan
+// dan email order harus sama dengan email akun, supaya tiket orang lain tidak bisa diambil alih
+// hanya dengan mendaftar memakai email yang sama.
+func (r *UserRepository) ClaimGuestOrders(ctx context.Context, userID int, bookingCodes []string) (models.ClaimOrdersResult, error) {
+	res := models.ClaimOrdersResult{OrderIDs: []int{}, NotFound: []string{}}
+
+	codes := make([]string, 0, len(bookingCodes))
+	for _, code := range bookingCodes {
+		codes = append(codes, strings.ToUpper(strings.TrimSpace(code)))
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE orders o SET id_user = a.id
+		FROM account a
+		WHERE a.id = $1
+			AND o.id_user IS NULL
+			AND LOWER(o.email) = LOWER(a.email)
+			AND UPPER(o.booking_code) = ANY($2::text[])
+		RETURNING o.id, UPPER(o.booking_code);
+	`
+	rows, err := tx.Query(ctx, query, userID, codes)
+	if err != nil {
+		return res, err
+	}
+	claimed := map[string]bool{}
+	for rows.Next() {
+		var id int
+		var code string
+		if err := rows.Scan(&id, &code); err != nil {
+			rows.Close()
+			return res, err
+		}
+		res.OrderIDs = append(res.OrderIDs, id)
+		claimed[code] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return res, err
+	}
+
+	// pemakaian promo ikut dipindah supaya batas per user tetap terhitung
+	if len(res.OrderIDs) > 0 {
+		if _, err := tx.Exec(ctx, `UPDATE promo_redemption SET id_user = $1 WHERE id_order = ANY($2) AND id_user IS NULL`, userID, res.OrderIDs); err != nil {
+			return res, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return res, err
+	}
+
+	for i, code := range codes {
+		if !claimed[code] {
+			res.NotFound = append(res.NotFound, bookingCodes[i])
+		}
+	}
+	slices.Sort(res.OrderIDs)
+	return res, nil
+}
+
+// GetWaitlist antrean waitlist user yang masih menunggu atau sedang ditawari kursi
+func (r *UserRepository) GetWaitlist(ctx context.Context, userID int) ([]models.WaitlistEntry, error) {
+	query := waitlistQuery + ` WHERE w.id_user = $1 AND w.status = ANY($2::varchar[]) ORDER BY s.date, t.time, w.id`
+	rows, err := r.db.Query(ctx, query, userID, []string{models.WaitlistStatusWaiting, models.WaitlistStatusOffered})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.WaitlistEntry{}
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, rows.Err()
+}
+
+// GetNotifications notifikasi terbaru user
+func (r *UserRepository) GetNotifications(ctx context.Context, userID, limit int) ([]models.Notification, error) {
+	query := `
+		SELECT id, type, message, id_schedule, read_at, create_at
+		FROM notifications
+		WHERE id_user = $1
+		ORDER BY id DESC
+		LIMIT $2;
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[models.Notification])
+}
+
+// ReadNotifications menandai semua notifikasi user sudah dibaca
+func (r *UserRepository) ReadNotifications(ctx context.Context, userID int) error {
+	_, err := r.db.Exec(ctx, `UPDATE notifications SET read_at = NOW() WHERE id_user = $1 AND read_at IS NULL`, userID)
+	return err
+}
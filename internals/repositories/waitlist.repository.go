@@ -0,0 +1,475 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrWaitlistNotSoldOut = errors.New("schedule still has enough seats available, book them directly")
+	ErrWaitlistJoined     = errors.New("already on the waitlist for this schedule")
+	ErrWaitlistNotFound   = errors.New("waitlist entry not found")
+	ErrWaitlistClosed     = errors.New("waitlist is closed because the schedule has started")
+)
+
+// waitlistQuery antrean beserta posisi dan ringkasan schedule-nya
+const waitlistQuery = `
+	SELECT
+		w.id, w.id_schedule, w.seat_count, w.status,
+		CASE WHEN w.status = 'waiting' THEN (
+			SELECT COUNT(*) FROM waitlist x WHERE x.id_schedule = w.id_schedule AND x.status = 'waiting' AND x.id <= w.id
+		) END,
+		COALESCE(w.seats, '{}'), w.offer_expires_at,
+		m.title, c.name, s.date, TO_CHAR(t.time, 'HH24:MI'), w.create_at
+	FROM waitlist w
+	JOIN schedule s ON s.id = w.id_schedule
+	JOIN movies m ON m.id = s.id_movie
+	JOIN cinema c ON c.id = s.id_cinema
+	JOIN time t ON t.id = s.id_time
+`
+
+func scanWaitlistEntry(row pgx.Row) (*models.WaitlistEntry, error) {
+	var w models.WaitlistEntry
+	err := row.Scan(
+		&w.ID, &w.ScheduleID, &w.SeatCount, &w.Status,
+		&w.Position,
+		&w.Seats, &w.OfferExpiresAt,
+		&w.MovieTitle, &w.CinemaName, &w.ShowDate, &w.ShowTime, &w.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWaitlistNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func getWaitlistEntry(ctx context.Context, db querier, id int) (*models.WaitlistEntry, error) {
+	return scanWaitlistEntry(db.QueryRow(ctx, waitlistQuery+` WHERE w.id = $1`, id))
+}
+
+// createNotification menyimpan notifikasi in-app untuk user
+func createNotification(ctx context.Context, db querier, userID, scheduleID int, kind, message string) error {
+	query := `INSERT INTO notifications (id_user, type, message, id_schedule) VALUES ($1, $2, $3, $4)`
+	_, err := db.Exec(ctx, query, userID, kind, message, scheduleID)
+	return err
+}
+
+// closeWaitlist menandai antrean user untuk schedule selesai karena user sudah memesan kursi
+func closeWaitlist(ctx context.Context, db querier, scheduleID, userID int) error {
+	query := `
+		UPDATE waitlist SET status = $3, update_at = NOW()
+		WHERE id_schedule = $1 AND id_user = $2 AND status = ANY($4::varchar[])
+	`
+	_, err := db.Exec(ctx, query, scheduleID, userID, models.WaitlistStatusBooked,
+		[]string{models.WaitlistStatusWaiting, models.WaitlistStatusOffered})
+	return err
+}
+
+// lockWaitlistSchedule mengunci baris schedule supaya antrean satu schedule diproses bergantian.
+// NO KEY UPDATE tidak menahan insert order yang hanya butuh KEY SHARE untuk foreign key.
+func lockWaitlistSchedule(ctx context.Context, tx pgx.Tx, scheduleID int) (title string, started bool, err error) {
+	query := `
+		SELECT m.title, LOCALTIMESTAMP >= s.date + t.time
+		FROM schedule s
+		JOIN movies m ON m.id = s.id_movie
+		JOIN time t ON t.id = s.id_time
+		WHERE s.id = $1 AND s.delete_at IS NULL
+		FOR NO KEY UPDATE OF s
+	`
+	if err := tx.QueryRow(ctx, query, scheduleID).Scan(&title, &started); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, ErrScheduleNotFound
+		}
+		return "", false, err
+	}
+	return title, started, nil
+}
+
+// waitlistSeats kursi studio schedule per blok beserta kursi yang sudah terjual atau ditahan.
+// Ruang kursi roda dan kursi pendamping yang belum dibuka untuk umum dianggap tidak bisa ditawarkan.
+func (r *SeatRepository) waitlistSeats(ctx context.Context, db querier, scheduleID int, accessibleRelease time.Duration) ([][]ruleSeat, map[string]bool, error) {
+	query := `
+		SELECT a.id, a.layout, LOCALTIMESTAMP + make_interval(secs => $2) >= s.date + t.time
+		FROM schedule s
+		JOIN auditorium a ON a.id = s.id_auditorium
+		JOIN time t ON t.id = s.id_time
+		WHERE s.id = $1 AND s.delete_at IS NULL
+	`
+	var auditoriumID int
+	var layout models.SeatLayout
+	var released bool
+	if err := db.QueryRow(ctx, query, scheduleID, accessibleRelease.Seconds()).Scan(&auditoriumID, &layout, &released); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrScheduleNotFound
+		}
+		return nil, nil, err
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT id_seat, row_label, col_number, disabled, pair_seat, access
+		FROM auditorium_seat
+		WHERE id_auditorium = $1
+		ORDER BY row_label, col_number
+	`, auditoriumID)
+	if err != nil {
+		return nil, nil, err
+	}
+	studioSeats, err := pgx.CollectRows(rows, pgx.RowToStructByPos[ruleSeat])
+	if err != nil {
+		return nil, nil, err
+	}
+	if !released {
+		for i := range studioSeats {
+			if studioSeats[i].Access != nil {
+				studioSeats[i].Disabled = true
+			}
+		}
+	}
+
+	taken := map[string]bool{}
+	rows, err = db.Query(ctx, `SELECT id_seat FROM orderdetails WHERE id_schedule = $1 AND released_at IS NULL`, scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sold, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, seat := range sold {
+		taken[seat] = true
+	}
+
+	holds, err := r.activeHolds(ctx, scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for seat := range holds {
+		taken[seat] = true
+	}
+
+	return seatBlocks(studioSeats, layout.Aisles), taken, nil
+}
+
+// pickSeats memilih n kursi kosong untuk ditawarkan: deretan bersebelahan yang tidak meninggalkan kursi kosong tunggal,
+// lalu deretan bersebelahan apa saja, terakhir kursi terpisah. Kursi couple hanya diambil berpasangan.
+// nil berarti kursi kosong tidak cukup.
+func pickSeats(blocks [][]ruleSeat, taken map[string]bool, n int) []string {
+	var fallback []string
+	for _, block := range blocks {
+		for start := 0; start+n <= len(block); start++ {
+			window := block[start : start+n]
+			ids := make([]string, 0, n)
+			for _, seat := range window {
+				if taken[seat.ID] || (seat.Pair != nil && !slices.ContainsFunc(window, func(s ruleSeat) bool { return s.ID == *seat.Pair })) {
+					ids = nil
+					break
+				}
+				ids = append(ids, seat.ID)
+			}
+			if ids == nil {
+				continue
+			}
+			if len(newOrphans(block, taken, ids)) == 0 {
+				return ids
+			}
+			if fallback == nil {
+				fallback = ids
+			}
+		}
+	}
+	if fallback != nil {
+		return fallback
+	}
+
+	available := map[string]bool{}
+	for _, block := range blocks {
+		for _, seat := range block {
+			if !taken[seat.ID] {
+				available[seat.ID] = true
+			}
+		}
+	}
+
+	var seats []string
+	for _, block := range blocks {
+		for _, seat := range block {
+			if len(seats) == n {
+				break
+			}
+			if !available[seat.ID] || slices.Contains(seats, seat.ID) {
+				continue
+			}
+			if seat.Pair == nil {
+				seats = append(seats, seat.ID)
+			} else if available[*seat.Pair] && len(seats)+2 <= n {
+				seats = append(seats, seat.ID, *seat.Pair)
+			}
+		}
+	}
+	if len(seats) < n {
+		return nil
+	}
+	slices.Sort(seats)
+	return seats
+}
+
+// JoinWaitlist memasukkan user ke antrean schedule. Antrean hanya dibuka jika kursi yang tersisa tidak cukup
+// atau masih ada antrean yang menunggu kursi yang baru lepas. Batas kursi per order dan per akun ikut berlaku.
+func (r *SeatRepository) JoinWaitlist(ctx context.Context, scheduleID, userID, seatCount int, limits SeatLimits, accessibleRelease time.Duration) (*models.WaitlistEntry, error) {
+	if seatCount > limits.PerOrder {
+		return nil, &SeatLimitError{Scope: models.SeatLimitScopeOrder, ScheduleID: scheduleID, Limit: limits.PerOrder, Requested: seatCount}
+	}
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, started, err := lockWaitlistSchedule(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if started {
+		return nil, ErrWaitlistClosed
+	}
+
+	owned, err := ownedSeats(ctx, tx, scheduleID, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if owned+seatCount > limits.PerUserSchedule {
+		return nil, &SeatLimitError{Scope: models.SeatLimitScopeSchedule, ScheduleID: scheduleID, Limit: limits.PerUserSchedule, Used: owned, Requested: seatCount}
+	}
+
+	var queued bool
+	query := `SELECT EXISTS (SELECT 1 FROM waitlist WHERE id_schedule = $1 AND status = $2)`
+	if err := tx.QueryRow(ctx, query, scheduleID, models.WaitlistStatusWaiting).Scan(&queued); err != nil {
+		return nil, err
+	}
+	if !queued {
+		blocks, taken, err := r.waitlistSeats(ctx, tx, scheduleID, accessibleRelease)
+		if err != nil {
+			return nil, err
+		}
+		if pickSeats(blocks, taken, seatCount) != nil {
+			return nil, ErrWaitlistNotSoldOut
+		}
+	}
+
+	var id int
+	query = `INSERT INTO waitlist (id_schedule, id_user, seat_count, status) VALUES ($1, $2, $3, $4) RETURNING id`
+	if err := tx.QueryRow(ctx, query, scheduleID, userID, seatCount, models.WaitlistStatusWaiting).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrWaitlistJoined
+		}
+		return nil, err
+	}
+
+	entry, err := getWaitlistEntry(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// LeaveWaitlist mengeluarkan user dari antrean schedule. Kursi yang sedang ditawarkan ke user ikut dilepas
+// supaya bisa ditawarkan ke antrean berikutnya.
+func (r *SeatRepository) LeaveWaitlist(ctx context.Context, scheduleID, userID int) (*models.WaitlistEntry, error) {
+	query := `
+		UPDATE waitlist w SET status = $3, update_at = NOW()
+		FROM (
+			SELECT id, status, seats FROM waitlist
+			WHERE id_schedule = $1 AND id_user = $2 AND status = ANY($4::varchar[])
+			FOR UPDATE
+		) old
+		WHERE w.id = old.id
+		RETURNING w.id, old.status, COALESCE(old.seats, '{}')
+	`
+
+	var id int
+	var status string
+	var seats []string
+	err := r.DB.QueryRow(ctx, query, scheduleID, userID, models.WaitlistStatusCancelled,
+		[]string{models.WaitlistStatusWaiting, models.WaitlistStatusOffered},
+	).Scan(&id, &status, &seats)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWaitlistNotFound
+		}
+		return nil, err
+	}
+
+	if status == models.WaitlistStatusOffered && len(seats) > 0 {
+		if _, err := r.ReleaseSeats(ctx, scheduleID, HoldOwnerUser(userID), seats); err != nil {
+			return nil, err
+		}
+	}
+
+	return getWaitlistEntry(ctx, r.DB, id)
+}
+
+// WaitlistSchedules schedule yang masih punya antrean menunggu atau tawaran yang berjalan
+func (r *SeatRepository) WaitlistSchedules(ctx context.Context) ([]int, error) {
+	query := `SELECT DISTINCT id_schedule FROM waitlist WHERE status = ANY($1::varchar[]) ORDER BY id_schedule`
+	rows, err := r.DB.Query(ctx, query, []string{models.WaitlistStatusWaiting, models.WaitlistStatusOffered})
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int])
+}
+
+// waitlistOffer kursi yang sudah di-hold di redis untuk antrean, dilepas lagi jika transaction gagal
+type waitlistOffer struct {
+	ID     int
+	UserID int
+	Seats  []string
+}
+
+// OfferWaitlist memproses antrean satu schedule. Tawaran yang lewat batas waktu di-expire dan kursinya dilepas,
+// lalu kursi kosong ditawarkan ke antrean secara berurutan: kursi di-hold atas nama user lewat script hold yang sama
+// dengan jalur booking biasa, sehingga kursi yang sudah diambil orang lain membuat tawaran dicoba lagi di putaran berikutnya.
+// Antrean tidak boleh disalip, proses berhenti di antrean pertama yang kursinya belum cukup.
+func (r *SeatRepository) OfferWaitlist(ctx context.Context, scheduleID int, ttl, accessibleRelease time.Duration) ([]models.WaitlistEntry, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	title, started, err := lockWaitlistSchedule(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	active := []string{models.WaitlistStatusWaiting, models.WaitlistStatusOffered}
+	if started {
+		query := `UPDATE waitlist SET status = $2, update_at = NOW() WHERE id_schedule = $1 AND status = ANY($3::varchar[])`
+		if _, err := tx.Exec(ctx, query, scheduleID, models.WaitlistStatusExpired, active); err != nil {
+			return nil, err
+		}
+		return nil, tx.Commit(ctx)
+	}
+
+	query := `
+		UPDATE waitlist SET status = $2, update_at = NOW()
+		WHERE id_schedule = $1 AND status = $3 AND offer_expires_at <= NOW()
+		RETURNING id, id_user, COALESCE(seats, '{}')
+	`
+	rows, err := tx.Query(ctx, query, scheduleID, models.WaitlistStatusExpired, models.WaitlistStatusOffered)
+	if err != nil {
+		return nil, err
+	}
+	lapsed, err := pgx.CollectRows(rows, pgx.RowToStructByPos[waitlistOffer])
+	if err != nil {
+		return nil, err
+	}
+	for _, offer := range lapsed {
+		if _, err := r.ReleaseSeats(ctx, scheduleID, HoldOwnerUser(offer.UserID), offer.Seats); err != nil {
+			return nil, err
+		}
+		message := fmt.Sprintf("Your seat offer for %s has expired and was passed to the next person in line", title)
+		if err := createNotification(ctx, tx, offer.UserID, scheduleID, models.NotificationWaitlistExpired, message); err != nil {
+			return nil, err
+		}
+	}
+
+	type waiting struct {
+		ID        int
+		UserID    int
+		SeatCount int
+	}
+	query = `SELECT id, id_user, seat_count FROM waitlist WHERE id_schedule = $1 AND status = $2 ORDER BY id`
+	rows, err = tx.Query(ctx, query, scheduleID, models.WaitlistStatusWaiting)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := pgx.CollectRows(rows, pgx.RowToStructByPos[waiting])
+	if err != nil {
+		return nil, err
+	}
+	if len(queue) == 0 {
+		return nil, tx.Commit(ctx)
+	}
+
+	blocks, taken, err := r.waitlistSeats(ctx, tx, scheduleID, accessibleRelease)
+	if err != nil {
+		return nil, err
+	}
+
+	var offers []waitlistOffer
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		for _, offer := range offers {
+			if _, err := r.ReleaseSeats(context.WithoutCancel(ctx), scheduleID, HoldOwnerUser(offer.UserID), offer.Seats); err != nil {
+				log.Printf("Failed to release waitlist hold : %s\n", err.Error())
+			}
+		}
+	}()
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	for _, w := range queue {
+		seats := pickSeats(blocks, taken, w.SeatCount)
+		if seats == nil {
+			break
+		}
+
+		args := []any{HoldOwnerUser(w.UserID), now.UnixMilli(), expiresAt.UnixMilli()}
+		for _, seat := range seats {
+			args = append(args, seat)
+		}
+		conflicts, err := holdSeatsScript.Run(ctx, r.RDB, []string{seatHoldKey(scheduleID)}, args...).StringSlice()
+		if err != nil {
+			return nil, err
+		}
+		if len(conflicts) > 0 {
+			break
+		}
+		offers = append(offers, waitlistOffer{ID: w.ID, UserID: w.UserID, Seats: seats})
+		for _, seat := range seats {
+			taken[seat] = true
+		}
+
+		query = `UPDATE waitlist SET status = $2, seats = $3, offer_expires_at = $4, update_at = NOW() WHERE id = $1`
+		if _, err := tx.Exec(ctx, query, w.ID, models.WaitlistStatusOffered, seats, expiresAt); err != nil {
+			return nil, err
+		}
+		message := fmt.Sprintf("Seats %s for %s are held for you until %s, complete your order before then",
+			strings.Join(seats, ", "), title, expiresAt.Format("15:04"))
+		if err := createNotification(ctx, tx, w.UserID, scheduleID, models.NotificationWaitlistOffer, message); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	committed = true
+
+	entries := make([]models.WaitlistEntry, 0, len(offers))
+	for _, offer := range offers {
+		entry, err := getWaitlistEntry(ctx, r.DB, offer.ID)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"github.com/Ntisrangga142/API_tickytiz/internals/handlers"
+	"github.com/Ntisrangga142/API_tickytiz/internals/middlewares"
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func InitCheckinRoute(router *gin.Engine, db *pgxpool.Pool) {
+	repo := repositories.NewCheckinRepo(db)
+	handler := handlers.NewCheckinHandler(repo)
+
+	router.POST("/checkin", middlewares.Authentication, middlewares.Authorization("staff"), handler.CheckIn)
+}
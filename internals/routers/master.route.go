@@ -13,6 +13,18 @@ func InitMasterRoute(router *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	repo := repositories.NewMasterRepo(db)
 	handler := handlers.NewMasterHandler(repo)
 
+	auditoriumRepo := repositories.NewAuditoriumRepo(db)
+	auditoriumHandler := handlers.NewAuditoriumHandler(auditoriumRepo)
+
+	seatClassRepo := repositories.NewSeatClassRepo(db)
+	seatClassHandler := handlers.NewSeatClassHandler(seatClassRepo)
+
+	pricingRepo := repositories.NewPricingRepo(db)
+	pricingHandler := handlers.NewPricingHandler(pricingRepo, rdb)
+
+	promoRepo := repositories.NewPromoRepo(db)
+	promoHandler := handlers.NewPromoHandler(promoRepo)
+
 	master := router.Group("/master")
 	{
 		master.GET("/directors", middlewares.Authentication, middlewares.Authorization("admin"), handler.GetDirectors)
@@ -20,5 +32,27 @@ func InitMasterRoute(router *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 		master.GET("/locations", middlewares.Authentication, middlewares.Authorization("admin"), handler.GetLocations)
 		master.GET("/times", middlewares.Authentication, middlewares.Authorization("admin"), handler.GetTimes)
 		master.GET("/cinemas", middlewares.Authentication, middlewares.Authorization("admin"), handler.GetCinemas)
+		master.PATCH("/cinemas/:id/seat-rules", middlewares.Authentication, middlewares.Authorization("admin"), handler.UpdateSeatRules)
+		master.GET("/cinemas/:id/auditoriums", middlewares.Authentication, middlewares.Authorization("admin"), auditoriumHandler.GetAuditoriums)
+		master.POST("/cinemas/:id/auditoriums", middlewares.Authentication, middlewares.Authorization("admin"), auditoriumHandler.CreateAuditorium)
+		master.GET("/auditoriums/:id", middlewares.Authentication, middlewares.Authorization("admin"), auditoriumHandler.GetAuditorium)
+		master.PUT("/auditoriums/:id", middlewares.Authentication, middlewares.Authorization("admin"), auditoriumHandler.UpdateAuditorium)
+		master.DELETE("/auditoriums/:id", middlewares.Authentication, middlewares.Authorization("admin"), auditoriumHandler.DeleteAuditorium)
+		master.GET("/seat-classes", middlewares.Authentication, middlewares.Authorization("admin"), seatClassHandler.GetSeatClasses)
+		master.POST("/seat-classes", middlewares.Authentication, middlewares.Authorization("admin"), seatClassHandler.CreateSeatClass)
+		master.PUT("/seat-classes/:id", middlewares.Authentication, middlewares.Authorization("admin"), seatClassHandler.UpdateSeatClass)
+		master.GET("/pricing-rules", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.GetPricingRules)
+		master.POST("/pricing-rules", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.CreatePricingRule)
+		master.PUT("/pricing-rules/:id", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.UpdatePricingRule)
+		master.DELETE("/pricing-rules/:id", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.DeletePricingRule)
+		master.GET("/holidays", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.GetHolidays)
+		master.POST("/holidays", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.CreateHoliday)
+		master.DELETE("/holidays/:id", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.DeleteHoliday)
+		master.GET("/schedules/:id/pricing", middlewares.Authentication, middlewares.Authorization("admin"), pricingHandler.PreviewPricing)
+		master.GET("/promo-codes", middlewares.Authentication, middlewares.Authorization("admin"), promoHandler.GetPromoCodes)
+		master.POST("/promo-codes", middlewares.Authentication, middlewares.Authorization("admin"), promoHandler.CreatePromoCode)
+		master.GET("/promo-codes/:id", middlewares.Authentication, middlewares.Authorization("admin"), promoHandler.GetPromoCode)
+		master.PUT("/promo-codes/:id", middlewares.Authentication, middlewares.Authorization("admin"), promoHandler.UpdatePromoCode)
+		master.DELETE("/promo-codes/:id", middlewares.Authentication, middlewares.Authorization("admin"), promoHandler.DeletePromoCode)
 	}
 }
@@ -3,17 +3,37 @@ package routers
 import (
 	"github.com/Ntisrangga142/API_tickytiz/internals/handlers"
 	"github.com/Ntisrangga142/API_tickytiz/internals/middlewares"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
 	repo "github.com/Ntisrangga142/API_tickytiz/internals/repositories"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
-func InitOrderRoute(router *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+func InitOrderRoute(router *gin.Engine, db *pgxpool.Pool, rdb *redis.Client, providers *payments.Registry) {
+	seatRepo := repo.NewSeatRepository(db, rdb)
 	repo := repo.NewOrderRepo(db)
-	handler := handlers.NewOrderHandler(repo, rdb)
+	handler := handlers.NewOrderHandler(repo, seatRepo, providers, rdb)
 
 	order := router.Group("/order")
-	order.POST("", middlewares.Authentication, middlewares.Authorization("user"), handler.CreateOrder)
+	order.POST("", middlewares.Authentication, middlewares.Authorization("user"), middlewares.Idempotency, handler.CreateOrder)
+	order.POST("/guest", middlewares.GuestSession, middlewares.Idempotency, handler.CreateGuestOrder)
+	order.POST("/quote", handler.QuoteOrder)
+	order.POST("/lookup", handler.LookupOrder)
+	order.POST("/lookup/pay", handler.PayLookupOrder)
+	order.GET("/conflicts", middlewares.Authentication, middlewares.Authorization("admin"), handler.GetOrderConflicts)
+	order.GET("/:id", middlewares.Authentication, middlewares.Authorization("user", "admin"), handler.GetOrder)
+	order.GET("/:id/qrcode", middlewares.Authentication, middlewares.Authorization("user"), handler.GetQRCode)
+	order.POST("/:id/pay", middlewares.Authentication, middlewares.Authorization("user"), handler.PayOrder)
+	order.POST("/:id/cancel", middlewares.Authentication, middlewares.Authorization("user"), handler.CancelOrder)
+	order.POST("/:id/change-seats", middlewares.Authentication, middlewares.Authorization("user"), middlewares.Idempotency, handler.ChangeSeats)
+	order.POST("/:id/transfer", middlewares.Authentication, middlewares.Authorization("user"), handler.TransferOrder)
+	order.POST("/:id/admin-cancel", middlewares.Authentication, middlewares.Authorization("admin"), handler.AdminCancelOrder)
+	order.PATCH("/:id/status", middlewares.Authentication, middlewares.Authorization("admin"), handler.UpdateOrderStatus)
 
+	transfer := router.Group("/transfer")
+	transfer.GET("", middlewares.Authentication, middlewares.Authorization("user"), handler.GetTransfers)
+	transfer.POST("/:id/accept", middlewares.Authentication, middlewares.Authorization("user"), handler.AcceptTransfer)
+	transfer.POST("/:id/decline", middlewares.Authentication, middlewares.Authorization("user"), handler.DeclineTransfer)
+	transfer.POST("/:id/cancel", middlewares.Authentication, middlewares.Authorization("user"), handler.CancelTransfer)
 }
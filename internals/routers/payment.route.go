@@ -2,13 +2,18 @@ package routers
 
 import (
 	"github.com/Ntisrangga142/API_tickytiz/internals/handlers"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
 	repo "github.com/Ntisrangga142/API_tickytiz/internals/repositories"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
-func InitPayment(r *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
+func InitPayment(r *gin.Engine, db *pgxpool.Pool, rdb *redis.Client, providers *payments.Registry, mock *payments.MockProvider, va *payments.VirtualAccountProvider) {
+
+	orderRepo := repo.NewOrderRepo(db)
+	seatRepo := repo.NewSeatRepository(db, rdb)
+	paymentHandler := handlers.NewPaymentHandler(orderRepo, seatRepo, providers, mock, va, rdb)
 
 	repo := repo.NewPaymentMethodRepository(db)
 	handler := handlers.NewPaymentMethodHandler(repo)
@@ -17,4 +22,11 @@ func InitPayment(r *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	{
 		group.GET("/", handler.GetAll)
 	}
+
+	payment := r.Group("/payment")
+	{
+		payment.POST("/webhook", paymentHandler.Webhook)
+		payment.POST("/sandbox/:reference", paymentHandler.Sandbox)
+		payment.POST("/va/:number/transfer", paymentHandler.SimulateTransfer)
+	}
 }
@@ -7,6 +7,7 @@ import (
 
 	docs "github.com/Ntisrangga142/API_tickytiz/docs"
 	"github.com/Ntisrangga142/API_tickytiz/internals/middlewares"
+	"github.com/Ntisrangga142/API_tickytiz/internals/payments"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -21,15 +22,21 @@ func InitRouter(db *pgxpool.Pool, rdb *redis.Client) *gin.Engine {
 	router.StaticFS("/payment-logo", gin.Dir("./public/payment_method", false))
 	router.StaticFS("/cinema", gin.Dir("./public/cinema", false))
 
+	// Payment provider, tambahkan adapter baru di sini lalu arahkan payment_method.provider ke namanya
+	mock := payments.NewMockProvider(rdb)
+	va := payments.NewVirtualAccountProvider(db)
+	providers := payments.NewRegistry(mock, va)
+
 	InitAuthRoutes(router, db, rdb)
 	InitMovieRoutes(router, db, rdb)
 	InitScheduleRoute(router, db, rdb)
-	InitOrderRoute(router, db, rdb)
+	InitOrderRoute(router, db, rdb, providers)
 	InitUserRoute(router, db, rdb)
 	InitAdminRoute(router, db, rdb)
 	InitRouteGenres(router, db)
-	InitPayment(router, db, rdb)
+	InitPayment(router, db, rdb, providers, mock, va)
 	InitMasterRoute(router, db, rdb)
+	InitCheckinRoute(router, db)
 
 	docs.SwaggerInfo.BasePath = "/"
 	router.GET("/tickytiz/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -2,6 +2,7 @@ package routers
 
 import (
 	"github.com/Ntisrangga142/API_tickytiz/internals/handlers"
+	"github.com/Ntisrangga142/API_tickytiz/internals/middlewares"
 	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,10 +13,18 @@ func InitScheduleRoute(router *gin.Engine, db *pgxpool.Pool, rdb *redis.Client)
 	repoSchedule := repositories.NewScheduleRepo(db)
 	handlerSchedule := handlers.NewScheduleHandler(repoSchedule, rdb)
 
-	repoSeat := repositories.NewSeatRepository(db)
+	repoSeat := repositories.NewSeatRepository(db, rdb)
 	handlerSeat := handlers.NewSeatHandler(repoSeat)
 
 	schedule := router.Group("/schedule")
 	schedule.GET("/:id", handlerSchedule.ScheduleMovie)
 	schedule.GET("/seat/:id", handlerSeat.GetSoldSeats)
+	schedule.POST("/seat/:id/hold", middlewares.Authentication, middlewares.Authorization("user"), handlerSeat.HoldSeats)
+	schedule.PATCH("/seat/:id/hold", middlewares.Authentication, middlewares.Authorization("user"), handlerSeat.ExtendHold)
+	schedule.DELETE("/seat/:id/hold", middlewares.Authentication, middlewares.Authorization("user"), handlerSeat.ReleaseSeats)
+	schedule.POST("/seat/:id/waitlist", middlewares.Authentication, middlewares.Authorization("user"), handlerSeat.JoinWaitlist)
+	schedule.DELETE("/seat/:id/waitlist", middlewares.Authentication, middlewares.Authorization("user"), handlerSeat.LeaveWaitlist)
+	schedule.POST("/seat/:id/guest-hold", middlewares.GuestSession, handlerSeat.HoldSeats)
+	schedule.PATCH("/seat/:id/guest-hold", middlewares.GuestSession, handlerSeat.ExtendHold)
+	schedule.DELETE("/seat/:id/guest-hold", middlewares.GuestSession, handlerSeat.ReleaseSeats)
 }
@@ -17,7 +17,12 @@ func InitUserRoute(r *gin.Engine, db *pgxpool.Pool, rdb *redis.Client) {
 	userGroup.GET("", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetProfile)
 	userGroup.PATCH("", middlewares.Authentication, middlewares.Authorization("user"), userHandler.UpdateProfile)
 	userGroup.PATCH("/password", middlewares.Authentication, middlewares.Authorization("user"), userHandler.ChangePassword)
-	userGroup.GET("/va", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetVirtualAccountHandler)
+	userGroup.GET("/va", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetVirtualAccounts)
 	userGroup.GET("/history", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetHistory)
+	userGroup.GET("/points", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetPoints)
+	userGroup.GET("/waitlist", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetWaitlist)
+	userGroup.GET("/notifications", middlewares.Authentication, middlewares.Authorization("user"), userHandler.GetNotifications)
+	userGroup.POST("/notifications/read", middlewares.Authentication, middlewares.Authorization("user"), userHandler.ReadNotifications)
+	userGroup.POST("/orders/claim", middlewares.Authentication, middlewares.Authorization("user"), userHandler.ClaimOrders)
 
 }
@@ -12,6 +12,11 @@ func HandleError(ctx *gin.Context, code int, status, err string) {
 	ctx.JSON(code, models.NewErrorResponse(status, err, code))
 }
 
+func HandleErrorWithData(ctx *gin.Context, code int, status, err string, data any) {
+	log.Printf("%s\nCause: %s\n", status, err)
+	ctx.JSON(code, models.NewErrorDataResponse(status, err, code, data))
+}
+
 func HandleMiddlewareError(ctx *gin.Context, code int, status, err string) {
 	log.Printf("%s\nCause: %s\n", status, err)
 	ctx.AbortWithStatusJSON(code, models.NewErrorResponse(status, err, code))
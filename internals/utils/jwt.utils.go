@@ -31,6 +31,28 @@ func GetUserIDFromJWT(ctx *gin.Context) (int, error) {
 	return claims.UserId, nil
 }
 
+func GetRoleFromJWT(ctx *gin.Context) (string, error) {
+	// Ambil header Authorization
+	authHeader := ctx.GetHeader("Authorization")
+	if authHeader == "" {
+		return "", errors.New("missing token")
+	}
+
+	// Buang prefix "Bearer "
+	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
+
+	// Siapkan struct Claims
+	claims := &pkg.Claims{}
+
+	// Verify token
+	if err := claims.VerifyToken(tokenStr); err != nil {
+		return "", err
+	}
+
+	// Ambil Role
+	return claims.Role, nil
+}
+
 func GetExpiredFromJWT(ctx *gin.Context) (time.Time, error) {
 	// Ambil header Authorization
 	authHeader := ctx.GetHeader("Authorization")
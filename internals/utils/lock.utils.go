@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lock hanya boleh dilepas oleh pemegang token yang sama
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// AcquireLock mencoba mengambil distributed lock, ok bernilai false jika lock sedang dipegang instance lain
+func AcquireLock(rctx context.Context, rdb *redis.Client, key string, ttl time.Duration) (string, bool, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", false, err
+	}
+	token := hex.EncodeToString(b)
+
+	ok, err := rdb.SetNX(rctx, key, token, ttl).Result()
+	if err != nil {
+		return "", false, err
+	}
+	return token, ok, nil
+}
+
+func ReleaseLock(rctx context.Context, rdb *redis.Client, key, token string) error {
+	return releaseLockScript.Run(rctx, rdb, []string{key}, token).Err()
+}
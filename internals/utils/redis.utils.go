@@ -3,6 +3,7 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"time"
 
@@ -56,3 +57,42 @@ func InvalidateCache(rctx context.Context, rdb *redis.Client, redisKey string) e
 	}
 	return nil
 }
+
+// InvalidateUserHistory menghapus semua halaman cache /user/history milik user
+func InvalidateUserHistory(rctx context.Context, rdb *redis.Client, userID int) error {
+	return invalidatePattern(rctx, rdb, fmt.Sprintf("Ntisrangga142-UserHistory-%d-*", userID))
+}
+
+// InvalidateSchedules menghapus semua cache /schedule/:id, dipakai ketika aturan harga berubah
+func InvalidateSchedules(rctx context.Context, rdb *redis.Client) error {
+	return invalidatePattern(rctx, rdb, "Ntisrangga142-Schedule-*")
+}
+
+// invalidatePattern menghapus semua key yang cocok dengan pattern redis
+func invalidatePattern(rctx context.Context, rdb *redis.Client, pattern string) error {
+	var keys []string
+	iter := rdb.Scan(rctx, 0, pattern, 100).Iterator()
+	for iter.Next(rctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("Redis Error.\nCause: %s\n", err)
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := rdb.Del(rctx, keys...).Err(); err != nil {
+		log.Printf("Redis Error.\nCause: %s\n", err)
+		return err
+	}
+	return nil
+}
+
+// InvalidateUserOrders menghapus cache user yang ikut berubah ketika order berubah: riwayat order dan profil (saldo poin)
+func InvalidateUserOrders(rctx context.Context, rdb *redis.Client, userID int) error {
+	if err := InvalidateUserHistory(rctx, rdb, userID); err != nil {
+		return err
+	}
+	return InvalidateCache(rctx, rdb, fmt.Sprintf("Ntisrangga142-UserProfiles-%d", userID))
+}
@@ -0,0 +1,123 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	orderExpiryLockKey = "Ntisrangga142-Lock-OrderExpiry"
+	orderExpiryBatch   = 100
+)
+
+// OrderExpiryWorker meng-expire order dan tukar kursi yang tidak dibayar sampai batas waktu lalu melepas kursinya.
+// Kursi yang lepas langsung ditawarkan ke waitlist schedule-nya jika Waitlist diisi.
+type OrderExpiryWorker struct {
+	Repo     *repositories.OrderRepo
+	Seats    *repositories.SeatRepository
+	Rdb      *redis.Client
+	Interval time.Duration
+	Waitlist *WaitlistWorker
+}
+
+func NewOrderExpiryWorker(repo *repositories.OrderRepo, seats *repositories.SeatRepository, rdb *redis.Client, interval time.Duration, waitlist *WaitlistWorker) *OrderExpiryWorker {
+	return &OrderExpiryWorker{Repo: repo, Seats: seats, Rdb: rdb, Interval: interval, Waitlist: waitlist}
+}
+
+// Run berjalan sampai ctx dibatalkan, panggil dengan goroutine
+func (w *OrderExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.RunOnce(ctx); err != nil {
+				log.Printf("Order expiry worker error.\nCause: %s\n", err)
+			}
+		}
+	}
+}
+
+// RunOnce satu putaran expiry. Hanya satu instance yang memegang lock redis dalam satu waktu.
+func (w *OrderExpiryWorker) RunOnce(ctx context.Context) error {
+	token, ok, err := utils.AcquireLock(ctx, w.Rdb, orderExpiryLockKey, w.Interval)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	defer func() {
+		if err := utils.ReleaseLock(ctx, w.Rdb, orderExpiryLockKey, token); err != nil {
+			log.Printf("Failed to release lock : %s\n", err.Error())
+		}
+	}()
+
+	for {
+		expired, err := w.Repo.ExpireOverdueOrders(ctx, orderExpiryBatch)
+		if err != nil {
+			return err
+		}
+
+		scheduleIDs := map[int]bool{}
+		for _, order := range expired {
+			log.Printf("Order %d expired, seats released\n", order.ID)
+			scheduleIDs[order.ScheduleID] = true
+			if order.UserID == nil {
+				continue
+			}
+			if err := utils.InvalidateUserOrders(ctx, w.Rdb, *order.UserID); err != nil {
+				log.Printf("Failed to invalidate chace : %s\n", err.Error())
+			}
+		}
+
+		if w.Waitlist != nil {
+			for scheduleID := range scheduleIDs {
+				w.Waitlist.Offer(ctx, scheduleID)
+			}
+		}
+
+		if len(expired) < orderExpiryBatch {
+			break
+		}
+	}
+
+	return w.expireSeatChanges(ctx)
+}
+
+// expireSeatChanges meng-expire tukar kursi yang charge selisihnya tidak dibayar lalu melepas hold kursi barunya
+func (w *OrderExpiryWorker) expireSeatChanges(ctx context.Context) error {
+	for {
+		expired, err := w.Repo.ExpireSeatChanges(ctx, orderExpiryBatch)
+		if err != nil {
+			return err
+		}
+
+		scheduleIDs := map[int]bool{}
+		for _, change := range expired {
+			log.Printf("Seat change %d expired, seat hold released\n", change.ChangeID)
+			scheduleIDs[change.ScheduleID] = true
+			if _, err := w.Seats.ReleaseSeats(ctx, change.ScheduleID, repositories.HoldOwnerSeatChange(change.ChangeID), nil); err != nil {
+				log.Printf("Failed to release seat hold : %s\n", err.Error())
+			}
+		}
+
+		if w.Waitlist != nil {
+			for scheduleID := range scheduleIDs {
+				w.Waitlist.Offer(ctx, scheduleID)
+			}
+		}
+
+		if len(expired) < orderExpiryBatch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Ntisrangga142/API_tickytiz/internals/repositories"
+	"github.com/Ntisrangga142/API_tickytiz/internals/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+const waitlistLockKey = "Ntisrangga142-Lock-Waitlist"
+
+// WaitlistWorker meng-expire tawaran waitlist yang tidak di-checkout dan menawarkan kursi kosong ke antrean berikutnya
+type WaitlistWorker struct {
+	Repo              *repositories.SeatRepository
+	Rdb               *redis.Client
+	Interval          time.Duration
+	OfferDuration     time.Duration
+	AccessibleRelease time.Duration
+}
+
+func NewWaitlistWorker(repo *repositories.SeatRepository, rdb *redis.Client, interval, offerDuration, accessibleRelease time.Duration) *WaitlistWorker {
+	return &WaitlistWorker{Repo: repo, Rdb: rdb, Interval: interval, OfferDuration: offerDuration, AccessibleRelease: accessibleRelease}
+}
+
+// Run berjalan sampai ctx dibatalkan, panggil dengan goroutine
+func (w *WaitlistWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.RunOnce(ctx); err != nil {
+				log.Printf("Waitlist worker error.\nCause: %s\n", err)
+			}
+		}
+	}
+}
+
+// RunOnce satu putaran untuk semua schedule yang punya antrean. Hanya satu instance yang memegang lock redis dalam satu waktu,
+// antrean satu schedule tetap aman diproses bersamaan dengan handler karena repository mengunci schedule-nya.
+func (w *WaitlistWorker) RunOnce(ctx context.Context) error {
+	token, ok, err := utils.AcquireLock(ctx, w.Rdb, waitlistLockKey, w.Interval)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	defer func() {
+		if err := utils.ReleaseLock(ctx, w.Rdb, waitlistLockKey, token); err != nil {
+			log.Printf("Failed to release lock : %s\n", err.Error())
+		}
+	}()
+
+	scheduleIDs, err := w.Repo.WaitlistSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, scheduleID := range scheduleIDs {
+		w.Offer(ctx, scheduleID)
+	}
+	return nil
+}
+
+// Offer memproses antrean satu schedule, dipakai juga oleh worker lain yang baru melepas kursi
+func (w *WaitlistWorker) Offer(ctx context.Context, scheduleID int) {
+	offers, err := w.Repo.OfferWaitlist(ctx, scheduleID, w.OfferDuration, w.AccessibleRelease)
+	if err != nil {
+		log.Printf("Failed to offer waitlist seats for schedule %d : %s\n", scheduleID, err.Error())
+		return
+	}
+	for _, offer := range offers {
+		log.Printf("Waitlist %d offered seats %v for schedule %d\n", offer.ID, offer.Seats, scheduleID)
+	}
+}
@@ -0,0 +1,84 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Alfabet booking code tanpa karakter yang mirip (0/O, 1/I)
+const bookingCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const ticketPrefix = "TKT"
+
+var ErrInvalidTicket = errors.New("invalid ticket code")
+
+type TicketPayload struct {
+	OrderID     int
+	ScheduleID  int
+	BookingCode string
+}
+
+// GenerateBookingCode membuat booking code acak 10 karakter
+func GenerateBookingCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = bookingCodeAlphabet[int(b[i])%len(bookingCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+func ticketSignature(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignTicket menghasilkan isi QR code
+// Format: TKT.<orderID>.<scheduleID>.<bookingCode>.<signature>
+func (p TicketPayload) SignTicket() (string, error) {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		return "", errors.New("no secret found")
+	}
+	data := fmt.Sprintf("%s.%d.%d.%s", ticketPrefix, p.OrderID, p.ScheduleID, p.BookingCode)
+	return fmt.Sprintf("%s.%s", data, ticketSignature(secret, data)), nil
+}
+
+// VerifyTicket memvalidasi signature isi QR code tanpa perlu akses database
+func VerifyTicket(token string) (*TicketPayload, error) {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		return nil, errors.New("no secret found")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 || parts[0] != ticketPrefix {
+		return nil, ErrInvalidTicket
+	}
+
+	data := strings.Join(parts[:4], ".")
+	if !hmac.Equal([]byte(ticketSignature(secret, data)), []byte(parts[4])) {
+		return nil, ErrInvalidTicket
+	}
+
+	orderID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+	scheduleID, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+
+	return &TicketPayload{OrderID: orderID, ScheduleID: scheduleID, BookingCode: parts[3]}, nil
+}
@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifyTicket(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "ticket-test-secret")
+
+	payload := TicketPayload{OrderID: 101, ScheduleID: 12, BookingCode: "P4ZN8WQ2HC"}
+	token, err := payload.SignTicket()
+	if err != nil {
+		t.Fatalf("SignTicket() error = %v", err)
+	}
+	parts := strings.Split(token, ".")
+
+	// tamperSignature mengganti karakter terakhir signature dengan karakter base64 lain
+	tamperSignature := func(sig string) string {
+		last := sig[len(sig)-1]
+		replacement := byte('A')
+		if last == 'A' {
+			replacement = 'B'
+		}
+		return sig[:len(sig)-1] + string(replacement)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid ticket", token: token},
+		{name: "tampered signature", token: strings.Join(append(parts[:4:4], tamperSignature(parts[4])), "."), wantErr: true},
+		{name: "tampered order id", token: strings.Join([]string{parts[0], "102", parts[2], parts[3], parts[4]}, "."), wantErr: true},
+		{name: "tampered booking code", token: strings.Join([]string{parts[0], parts[1], parts[2], "AAAAAAAAAA", parts[4]}, "."), wantErr: true},
+		{name: "missing signature", token: strings.Join(parts[:4], "."), wantErr: true},
+		{name: "empty signature", token: strings.Join(parts[:4], ".") + ".", wantErr: true},
+		{name: "wrong prefix", token: strings.Join(append([]string{"QR"}, parts[1:]...), "."), wantErr: true},
+		{name: "extra part", token: token + ".x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyTicket(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTicket) {
+					t.Fatalf("VerifyTicket() error = %v, want %v", err, ErrInvalidTicket)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyTicket() error = %v", err)
+			}
+			if *got != payload {
+				t.Errorf("VerifyTicket() = %+v, want %+v", *got, payload)
+			}
+		})
+	}
+}
+
+func TestVerifyTicketOtherSecret(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "ticket-test-secret")
+	token, err := TicketPayload{OrderID: 101, ScheduleID: 12, BookingCode: "P4ZN8WQ2HC"}.SignTicket()
+	if err != nil {
+		t.Fatalf("SignTicket() error = %v", err)
+	}
+
+	t.Setenv("TICKET_SECRET", "another-secret")
+	if _, err := VerifyTicket(token); !errors.Is(err, ErrInvalidTicket) {
+		t.Errorf("VerifyTicket() error = %v, want %v", err, ErrInvalidTicket)
+	}
+}